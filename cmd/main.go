@@ -1,7 +1,566 @@
 package main
 
-import "github.com/arshpsps/gitls/internals"
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/arshpsps/gitls/internals"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// version is set at build time with -ldflags "-X main.version=v1.2.3".
+var version = "dev"
 
 func main() {
-	internals.BbltRun()
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "gitls: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	var (
+		debug    bool
+		profile  string
+		fromFile string
+		plain    bool
+		line     bool
+		record   string
+		replay   string
+		debugLog io.Closer
+	)
+
+	root := &cobra.Command{
+		Use:   "gitls",
+		Short: "Browse and clone GitHub and GitLab repositories from the terminal",
+		Long: "gitls lists the repositories of a user or organization in a TUI and clones them.\n" +
+			"Without a subcommand it opens the TUI, like gitls browse.",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			line = line || os.Getenv("TERM") == "dumb"
+			internals.SetPlain(plain || line || os.Getenv("NO_COLOR") != "")
+			internals.SetLineMode(line)
+
+			if debug {
+				path, f, err := internals.EnableDebug()
+				if err != nil {
+					return fmt.Errorf("could not enable debug logging: %w", err)
+				}
+				debugLog = f
+				fmt.Fprintf(os.Stderr, "debug log: %s\n", path)
+			}
+
+			if err := internals.LoadConfig(); err != nil {
+				return fmt.Errorf("could not load config: %w", err)
+			}
+			internals.SelectLocale()
+			if err := internals.UseProfile(profile); err != nil {
+				return err
+			}
+			if record != "" {
+				if err := internals.StartRecording(record); err != nil {
+					return fmt.Errorf("could not start recording: %w", err)
+				}
+			}
+			return nil
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if debugLog != nil {
+				debugLog.Close()
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if replay != "" {
+				return internals.Replay(replay)
+			}
+			if fromFile != "" {
+				return internals.AccountsRun(fromFile)
+			}
+			internals.BbltRun()
+			return nil
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.BoolVar(&debug, "debug", false, "write debug logs to the gitls state directory")
+	flags.StringVar(&profile, "profile", "", "named profile from the config file")
+	flags.BoolVar(&plain, "plain", false, "disable colors, borders and symbols (also enabled by NO_COLOR)")
+	flags.BoolVar(&line, "line", false, "browse with line-by-line prompts instead of the full-screen list (also enabled by TERM=dumb)")
+	flags.StringVar(&record, "record", "", "log the accounts opened, list filters and clones of this run to a JSON file")
+	flags.Var(watchFlag{}, "watch", "refresh the repository list in the background at this interval, e.g. 5m")
+	root.CompletionOptions.DisableDefaultCmd = true
+	root.RegisterFlagCompletionFunc("profile", completeProfiles)
+	root.Flags().StringVar(&replay, "replay", "", "repeat the clones of a session recorded with --record, without the TUI")
+	root.Flags().StringVar(&fromFile, "from-file", "", "pick an account from a file listing users and orgs, one per line (- for stdin)")
+	addPickFlag(root)
+
+	root.AddCommand(
+		newBrowseCmd(),
+		newListCmd(),
+		newCloneCmd(),
+		newSyncCmd(),
+		newMirrorCmd(),
+		newExportCmd(),
+		newTrendingCmd(),
+		newLocalCmd(),
+		newHistoryCmd(),
+		newTrashCmd(),
+		newScaffoldCmd(),
+		newSetupCmd(),
+		newLoginCmd(),
+		newLogoutCmd(),
+		newVersionCmd(),
+		newSelfUpdateCmd(),
+		newCompletionCmd(root),
+	)
+	return root
+}
+
+// watchFlag sets the refresh interval as soon as it is parsed.
+type watchFlag struct{}
+
+func (watchFlag) String() string { return "0s" }
+func (watchFlag) Type() string   { return "duration" }
+
+func (watchFlag) Set(v string) error {
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return err
+	}
+	internals.SetRefreshInterval(d)
+	return nil
+}
+
+func newBrowseCmd() *cobra.Command {
+	var fromFile string
+	cmd := &cobra.Command{
+		Use:               "browse [user|org]",
+		Short:             "Open the TUI, for the given account or the configured one",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeAccounts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromFile != "" {
+				return internals.AccountsRun(fromFile)
+			}
+			var account string
+			if len(args) > 0 {
+				account = args[0]
+			}
+			internals.BrowseRun(account)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&fromFile, "from-file", "", "pick an account from a file listing users and orgs, one per line (- for stdin)")
+	addPickFlag(cmd)
+	return cmd
+}
+
+// pickFlag turns on picker mode as soon as it is parsed.
+type pickFlag struct{}
+
+func (pickFlag) String() string     { return "" }
+func (pickFlag) Type() string       { return "field" }
+func (pickFlag) Set(v string) error { return internals.SetPick(v) }
+
+func addPickFlag(cmd *cobra.Command) {
+	cmd.Flags().Var(pickFlag{}, "pick", "print the selected repository's url, name or path on enter instead of cloning it")
+	cmd.Flags().Lookup("pick").NoOptDefVal = "url"
+}
+
+func addSelectFlags(cmd *cobra.Command, include, exclude *[]string, verb string) {
+	cmd.Flags().StringArrayVar(include, "include", nil, "only "+verb+" repos whose name matches this glob (repeatable)")
+	cmd.Flags().StringArrayVar(exclude, "exclude", nil, "skip repos whose name matches this glob (repeatable)")
+}
+
+// addBatchFlags registers the flags clone and sync share. Defaults that
+// depend on the config are filled in by batchDefaults once it is loaded.
+func addBatchFlags(cmd *cobra.Command, opts *internals.BatchOptions, verb string) {
+	addSelectFlags(cmd, &opts.Include, &opts.Exclude, verb)
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 4, "number of parallel clones/pulls")
+	cmd.Flags().StringVar(&opts.Dir, "dir", "", "directory to clone into (default: the profile's clone directory or .)")
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "partial clone filter, e.g. blob:none or tree:0 (default from partial_clone; empty for full clones)")
+	cmd.Flags().StringVar(&opts.Layout, "layout", "", "flat (<dir>/<repo>) or ghq (<dir>/<host>/<owner>/<repo>) (default from the config)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "print what would happen without touching the filesystem")
+}
+
+func batchDefaults(cmd *cobra.Command, opts *internals.BatchOptions) {
+	if opts.Dir == "" {
+		opts.Dir = internals.DefaultCloneDir()
+	}
+	if !cmd.Flags().Changed("filter") {
+		opts.Filter = internals.DefaultCloneFilter()
+	}
+	if opts.Layout == "" {
+		opts.Layout = internals.DefaultLayout()
+	}
+}
+
+func newCloneCmd() *cobra.Command {
+	var opts internals.BatchOptions
+	cmd := &cobra.Command{
+		Use:   "clone <user|org>",
+		Short: "Clone an account's repositories without the TUI",
+		Example: "  gitls clone charmbracelet --all\n" +
+			"  gitls clone charmbracelet --include 'bubble*' --filter blob:none",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAccounts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			batchDefaults(cmd, &opts)
+			return internals.CloneAll(args[0], opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.All, "all", false, "clone every repository of the account")
+	cmd.Flags().BoolVar(&opts.Mirror, "mirror", false, "create bare mirror clones (git clone --mirror)")
+	addBatchFlags(cmd, &opts, "clone")
+	return cmd
+}
+
+func newSyncCmd() *cobra.Command {
+	opts := internals.BatchOptions{All: true}
+	cmd := &cobra.Command{
+		Use:               "sync <user|org>",
+		Short:             "Clone missing repositories and fast-forward existing clones",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAccounts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			batchDefaults(cmd, &opts)
+			return internals.SyncAll(args[0], opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.Mirror, "mirror", false, "keep bare mirror clones instead of working copies")
+	addBatchFlags(cmd, &opts, "sync")
+	return cmd
+}
+
+func newMirrorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mirror",
+		Short: "Keep bare mirrors of an account up to date from a manifest",
+		Long: "mirror init writes a manifest listing an account's repositories and where to mirror them.\n" +
+			"mirror run clones missing mirrors and fetches the others; run it from cron or a systemd timer.",
+	}
+	cmd.AddCommand(newMirrorInitCmd(), newMirrorRunCmd())
+	return cmd
+}
+
+func newMirrorInitCmd() *cobra.Command {
+	var opts internals.MirrorOptions
+	cmd := &cobra.Command{
+		Use:               "init <user|org>",
+		Short:             "Write a mirror manifest for an account",
+		Example:           "  gitls mirror init charmbracelet --dir /srv/backup/charm",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAccounts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Dir == "" {
+				opts.Dir = internals.DefaultCloneDir()
+			}
+			if opts.Layout == "" {
+				opts.Layout = internals.DefaultLayout()
+			}
+			return internals.MirrorInit(args[0], opts)
+		},
+	}
+	addSelectFlags(cmd, &opts.Include, &opts.Exclude, "mirror")
+	cmd.Flags().StringVar(&opts.Dir, "dir", "", "directory for the mirrors and the manifest (default: the profile's clone directory or .)")
+	cmd.Flags().StringVar(&opts.Layout, "layout", "", "flat (<dir>/<repo>.git) or ghq (<dir>/<host>/<owner>/<repo>.git) (default from the config)")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 4, "number of parallel clones/fetches")
+	cmd.Flags().BoolVar(&opts.TrackNew, "track-new", true, "add repositories created later on each run")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "replace an existing manifest")
+	return cmd
+}
+
+func newMirrorRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "run [manifest]",
+		Short:   "Clone or fetch every mirror in a manifest",
+		Long:    "Brings the mirrors in the manifest (default ./" + internals.MirrorManifestName + ") up to date. Running it again\nonly fetches, and it exits non-zero if any mirror failed.",
+		Example: "  gitls mirror run /srv/backup/charm/" + internals.MirrorManifestName,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := internals.MirrorManifestName
+			if len(args) > 0 {
+				path = args[0]
+			}
+			return internals.MirrorRun(path)
+		},
+	}
+}
+
+func newTrashCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "List, restore or empty clones removed by undo or overwrite",
+		Long: "Undoing a clone and overwriting a destination move the directory into the gitls trash\n" +
+			"instead of deleting it. Without a subcommand, trash lists what is there.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return internals.PrintTrash()
+		},
+	}
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List trashed directories",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return internals.PrintTrash()
+		},
+	}
+
+	var to string
+	restore := &cobra.Command{
+		Use:     "restore <id|path>",
+		Short:   "Move a trashed directory back",
+		Example: "  gitls trash restore ~/src/gitls\n  gitls trash restore 20250101-120000-gitls --to ~/src/gitls-old",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return internals.TrashRestore(args[0], to)
+		},
+	}
+	restore.Flags().StringVar(&to, "to", "", "restore here instead of the original path")
+
+	var olderThan int
+	empty := &cobra.Command{
+		Use:   "empty [id|path]",
+		Short: "Delete trashed directories for good",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			what := ""
+			if len(args) > 0 {
+				what = args[0]
+			}
+			return internals.TrashEmpty(what, time.Duration(olderThan)*24*time.Hour)
+		},
+	}
+	empty.Flags().IntVar(&olderThan, "older-than", 0, "only delete what was trashed more than this many days ago")
+
+	cmd.AddCommand(list, restore, empty)
+	return cmd
+}
+
+func newScaffoldCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "scaffold <dir> [name]",
+		Short: "Turn a clone of a template repository into a new project",
+		Long: "scaffold substitutes the placeholders in the scaffold config key and the template's Go\n" +
+			"module path in the files below dir. name defaults to the directory's name.",
+		Example: "  gitls scaffold ~/src/myservice\n  gitls scaffold ./go-template myservice",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) > 1 {
+				name = args[1]
+			}
+			return internals.Scaffold(args[0], name)
+		},
+	}
+}
+
+func newListCmd() *cobra.Command {
+	opts := internals.ExportOptions{Out: "-"}
+	cmd := &cobra.Command{
+		Use:               "list <user|org>",
+		Short:             "Print an account's repositories",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAccounts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return internals.Export(args[0], opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.Format, "format", "table", "table, json, csv or urls")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "name", "sort by name, stars or updated")
+	addSelectFlags(cmd, &opts.Include, &opts.Exclude, "list")
+	return cmd
+}
+
+func newExportCmd() *cobra.Command {
+	var opts internals.ExportOptions
+	cmd := &cobra.Command{
+		Use:   "export <user|org>",
+		Short: "Write an account's repositories to a JSON, CSV or URL list file",
+		Example: "  gitls export charmbracelet --out repos.csv --sort stars\n" +
+			"  gitls export charmbracelet | xargs -n1 git clone",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAccounts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return internals.Export(args[0], opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.Out, "out", "-", "file to write (- for stdout)")
+	cmd.Flags().StringVar(&opts.Format, "format", "", "json, csv or urls (default: from the --out extension, urls for stdout)")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "name", "sort by name, stars or updated")
+	addSelectFlags(cmd, &opts.Include, &opts.Exclude, "export")
+	return cmd
+}
+
+func newTrendingCmd() *cobra.Command {
+	var language, since string
+	cmd := &cobra.Command{
+		Use:   "trending",
+		Short: "Browse the most starred recently created repositories",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return internals.TrendingRun(language, since)
+		},
+	}
+	cmd.Flags().StringVar(&language, "language", "", "only repositories in this language")
+	cmd.Flags().StringVar(&since, "since", "", "day, week or month (prompt for language and period when empty)")
+	return cmd
+}
+
+func newLocalCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "local [dir]",
+		Short: "Browse the git repositories below a directory",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return internals.LocalRun(dir)
+		},
+	}
+}
+
+func newHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history",
+		Short: "Print the clone history",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return internals.PrintHistory()
+		},
+	}
+}
+
+func newVersionCmd() *cobra.Command {
+	var check bool
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the gitls version",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println("gitls", version)
+			if check {
+				return internals.CheckUpdate(version)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&check, "check", false, "check whether a newer release is available")
+	return cmd
+}
+
+func newSelfUpdateCmd() *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Replace this binary with the latest release",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return internals.SelfUpdate(version, force)
+		},
+	}
+	cmd.Flags().BoolVar(&force, "force", false, "update development builds and reinstall the current release")
+	return cmd
+}
+
+func newSetupCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "setup",
+		Short: "Run the first-run setup wizard again",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			internals.SetupRun()
+		},
+	}
+}
+
+func newLoginCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "login",
+		Short: "Store an access token in the system keychain",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogin()
+		},
+	}
+}
+
+func newLogoutCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Remove the stored access token",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return internals.Logout()
+		},
+	}
+}
+
+func newCompletionCmd(root *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish",
+		Short: "Print a shell completion script",
+		Long: `Print a completion script for the given shell. Besides commands and flags it
+completes recently browsed accounts and configured profiles.
+
+  bash:  source <(gitls completion bash)
+  zsh:   gitls completion zsh > "${fpath[1]}/_gitls"
+  fish:  gitls completion fish > ~/.config/fish/completions/gitls.fish`,
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		// completion must work before a config exists
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				return root.GenZshCompletion(os.Stdout)
+			default:
+				return root.GenFishCompletion(os.Stdout, true)
+			}
+		},
+	}
+}
+
+// completeAccounts offers recently browsed accounts for the account
+// argument. Completion runs without the root pre-run, so the config is
+// loaded here.
+func completeAccounts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	internals.LoadConfig()
+	return internals.RecentAccounts(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	internals.LoadConfig()
+	return internals.ProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func runLogin() error {
+	fmt.Print("Paste a personal access token: ")
+	var token []byte
+	var err error
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		token, err = term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+	} else {
+		token, err = bufio.NewReader(os.Stdin).ReadBytes('\n')
+	}
+	if err != nil && len(token) == 0 {
+		return err
+	}
+	return internals.Login(strings.TrimSpace(string(token)))
 }
@@ -0,0 +1,125 @@
+package internals
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type account struct {
+	name    string
+	comment string
+}
+
+func (a account) Title() string       { return a.name }
+func (a account) Description() string { return a.comment }
+func (a account) FilterValue() string { return a.name + " " + a.comment }
+
+// parseAccounts reads one user or org per line. Blank lines and lines
+// starting with # are skipped, and text after a # is shown as a comment.
+func parseAccounts(r io.Reader) ([]account, error) {
+	var accounts []account
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name, comment, _ := strings.Cut(scanner.Text(), "#")
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		accounts = append(accounts, account{name: name, comment: strings.TrimSpace(comment)})
+	}
+	return accounts, scanner.Err()
+}
+
+type accountsModel struct {
+	list   list.Model
+	width  int
+	height int
+}
+
+func newAccountsModel(accounts []account) accountsModel {
+	items := make([]list.Item, len(accounts))
+	for i, a := range accounts {
+		items[i] = a
+	}
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	localizeList(&l)
+	l.Title = tr("Accounts")
+	l.SetSize(80, 24)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", tr("browse account"))),
+		}
+	}
+	return accountsModel{list: l}
+}
+
+func (m accountsModel) crumbs() []string { return []string{"accounts"} }
+
+func (m accountsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m accountsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "enter":
+			a, ok := m.list.SelectedItem().(account)
+			if !ok {
+				return m, nil
+			}
+			return m, navigate(initialModel(a.name))
+		}
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		h, v := normalStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m accountsModel) View() string {
+	return normalStyle.Render(m.list.View())
+}
+
+// AccountsRun reads a list of users and orgs from path ("-" for stdin) and
+// lets the user pick one to browse.
+func AccountsRun(path string) error {
+	r := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(expandHome(path))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	accounts, err := parseAccounts(r)
+	if err != nil {
+		return err
+	}
+
+	if path == "-" {
+		// stdin is used up by the list, read keys from the terminal instead
+		runProgram(newAccountsModel(accounts), tea.WithInputTTY())
+	} else {
+		runProgram(newAccountsModel(accounts))
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package internals
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v50/github"
+)
+
+// ciDelay is how long a repository has to stay selected before its
+// workflow status is fetched, so scrolling through the list doesn't fire a
+// request per repository.
+const ciDelay = 400 * time.Millisecond
+
+// ciStatus is the outcome of the latest workflow run on the default branch.
+type ciStatus struct {
+	state   string // passing, failing, running, cancelled or none
+	pending bool
+	err     error
+}
+
+func (s ciStatus) String() string {
+	switch {
+	case s.pending:
+		return "loading..."
+	case s.err != nil:
+		return errorStyle.Render(tr("unavailable"))
+	}
+	switch s.state {
+	case "passing":
+		return successStyle.Render(glyph("✓ ", "") + s.state)
+	case "failing":
+		return errorStyle.Render(glyph("✗ ", "") + s.state)
+	case "running":
+		return glyph("● ", "") + s.state
+	case "none":
+		return badgeStyle.Render(tr("no workflow runs"))
+	default:
+		return s.state
+	}
+}
+
+// ciCache holds workflow statuses by owner/name for the session.
+var ciCache sync.Map
+
+func ciStatusFor(it item) (ciStatus, bool) {
+	v, ok := ciCache.Load(it.owner + "/" + it.name)
+	if !ok {
+		return ciStatus{}, false
+	}
+	return v.(ciStatus), true
+}
+
+func runState(run *github.WorkflowRun) string {
+	if run.GetStatus() != "completed" {
+		return "running"
+	}
+	switch run.GetConclusion() {
+	case "success", "neutral", "skipped":
+		return "passing"
+	case "cancelled":
+		return "cancelled"
+	default:
+		return "failing"
+	}
+}
+
+type ciWantedMsg struct{ repo string }
+
+// ciFetchedMsg only triggers a redraw; the status is already in ciCache,
+// so it isn't lost if another screen is in front when it arrives.
+type ciFetchedMsg struct{}
+
+// wantCIStatus asks for the selected repository's workflow status once it
+// has been selected for ciDelay, unless it is known already or the detail
+// pane is hidden.
+func (m repoModel) wantCIStatus() tea.Cmd {
+	it, ok := m.list.SelectedItem().(item)
+	if !ok || m.layout() == layoutList || activeProfile.provider() != providerGitHub {
+		return nil
+	}
+	if _, limited := rateLimited(); limited {
+		return nil
+	}
+	if _, ok := ciStatusFor(it); ok {
+		return nil
+	}
+	repo := it.owner + "/" + it.name
+	return tea.Tick(ciDelay, func(time.Time) tea.Msg { return ciWantedMsg{repo: repo} })
+}
+
+func (m repoModel) fetchCIStatus(msg ciWantedMsg) tea.Cmd {
+	it, ok := m.list.SelectedItem().(item)
+	if !ok || it.owner+"/"+it.name != msg.repo {
+		return nil
+	}
+	if _, loaded := ciCache.LoadOrStore(msg.repo, ciStatus{pending: true}); loaded {
+		return nil
+	}
+	return func() tea.Msg {
+		ciCache.Store(msg.repo, loadCIStatus(it))
+		return ciFetchedMsg{}
+	}
+}
+
+func loadCIStatus(it item) ciStatus {
+	opts := &github.ListWorkflowRunsOptions{
+		Branch:      it.defaultBranch,
+		ListOptions: github.ListOptions{PerPage: 1},
+	}
+	runs, _, err := newAPI().ListWorkflowRuns(context.Background(), it.owner, it.name, opts)
+	if err != nil {
+		logger.Debug("workflow runs", "repo", it.owner+"/"+it.name, "err", err)
+		return ciStatus{err: err}
+	}
+	if len(runs.WorkflowRuns) == 0 {
+		return ciStatus{state: "none"}
+	}
+	return ciStatus{state: runState(runs.WorkflowRuns[0])}
+}
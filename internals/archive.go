@@ -0,0 +1,20 @@
+package internals
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type archiveFinishedMsg struct {
+	dir string
+	err error
+}
+
+func downloadArchive(it item) tea.Cmd {
+	return func() tea.Msg {
+		dest := cloneTarget(it)
+		err := newClient().DownloadArchive(context.Background(), it.owner, it.name, it.defaultBranch, dest)
+		return archiveFinishedMsg{dir: dest, err: err}
+	}
+}
@@ -0,0 +1,393 @@
+package internals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	"github.com/google/go-github/v50/github"
+)
+
+type BatchOptions struct {
+	All         bool
+	Mirror      bool
+	Include     []string
+	Exclude     []string
+	Concurrency int
+	Dir         string
+	DryRun      bool
+	Filter      string // partial clone filter spec, empty for full clones
+	Layout      string // flat or ghq
+}
+
+type batchStatus int
+
+const (
+	batchCloned batchStatus = iota
+	batchUpdated
+	batchUnchanged
+	batchSkipped
+	batchFailed
+)
+
+func (s batchStatus) String() string {
+	switch s {
+	case batchCloned:
+		return "cloned"
+	case batchUpdated:
+		return "updated"
+	case batchUnchanged:
+		return "unchanged"
+	case batchSkipped:
+		return "skipped"
+	default:
+		return "failed"
+	}
+}
+
+func (s batchStatus) symbol() string {
+	switch s {
+	case batchCloned:
+		return glyph("✓", "+")
+	case batchUpdated:
+		return glyph("↻", "~")
+	case batchUnchanged:
+		return "="
+	case batchSkipped:
+		return "-"
+	default:
+		return glyph("✗", "x")
+	}
+}
+
+type batchResult struct {
+	name   string
+	status batchStatus
+	err    error
+	bytes  int64 // growth of the git directory, roughly what was fetched
+	took   time.Duration
+}
+
+// gitDirSize is the size of the repository at dest's object store, the
+// part that grows by what a clone or fetch downloads.
+func gitDirSize(dest string, bare bool) int64 {
+	if !bare {
+		dest = filepath.Join(dest, ".git")
+	}
+	var size int64
+	filepath.WalkDir(dest, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func selectRepos(repos []*github.Repository, opts BatchOptions) []*github.Repository {
+	var selected []*github.Repository
+	for _, repo := range repos {
+		name := repo.GetName()
+		if len(opts.Include) > 0 && !matchAny(opts.Include, name) {
+			continue
+		}
+		if matchAny(opts.Exclude, name) {
+			continue
+		}
+		selected = append(selected, repo)
+	}
+	return selected
+}
+
+func cloneDest(repo *github.Repository, opts BatchOptions) string {
+	name := repo.GetName()
+	if opts.Mirror {
+		name += ".git"
+	}
+	return gitls.Dest(gitls.Layout(opts.Layout), opts.Dir, cloneURL(repo), repo.GetOwner().GetLogin(), name)
+}
+
+func cloneOne(repo *github.Repository, opts BatchOptions) batchResult {
+	return cloneOneTo(repo, cloneDest(repo, opts), opts)
+}
+
+func cloneOneTo(repo *github.Repository, dest string, opts BatchOptions) batchResult {
+	if _, err := os.Stat(dest); err == nil {
+		return batchResult{name: repo.GetName(), status: batchSkipped}
+	}
+
+	it := newItem(repo)
+	if err := runHook(newHookPayload(hookPreClone, it, dest)); err != nil {
+		return batchResult{name: repo.GetName(), status: batchSkipped, err: err}
+	}
+	cloneOpts := gitls.CloneOptions{Mirror: opts.Mirror, Filter: opts.Filter}
+	err := gitRunner().Clone(context.Background(), cloneURL(repo), dest, cloneOpts)
+	recordClone(repo.GetFullName(), cloneURL(repo), dest, err)
+	if err != nil {
+		return batchResult{name: repo.GetName(), status: batchFailed, err: errors.New(gitFailure(err, cloneURL(repo), dest))}
+	}
+	recordSessionClone(repo.GetFullName(), cloneURL(repo), dest, cloneOpts, nil)
+	bytes := gitDirSize(dest, opts.Mirror)
+	if err := runHook(newHookPayload(hookPostClone, it, dest)); err != nil {
+		return batchResult{name: repo.GetName(), status: batchFailed, err: err, bytes: bytes}
+	}
+	return batchResult{name: repo.GetName(), status: batchCloned, bytes: bytes}
+}
+
+// CloneAll clones every repository of account that matches opts without
+// starting the TUI and prints a summary report.
+func CloneAll(account string, opts BatchOptions) error {
+	if !opts.All && len(opts.Include) == 0 {
+		return errors.New("nothing selected: pass --all or at least one --include pattern")
+	}
+	return runBatch(account, opts, cloneOne, false)
+}
+
+// SyncAll clones repositories of account that are missing from opts.Dir and
+// updates the ones that already exist.
+func SyncAll(account string, opts BatchOptions) error {
+	return runBatch(account, opts, syncOne, true)
+}
+
+func syncOne(repo *github.Repository, opts BatchOptions) batchResult {
+	return syncOneTo(repo, cloneDest(repo, opts), opts)
+}
+
+func syncOneTo(repo *github.Repository, dest string, opts BatchOptions) batchResult {
+	if _, err := os.Stat(dest); err != nil {
+		return cloneOneTo(repo, dest, opts)
+	}
+
+	// mirrors have no work tree, so compare all refs instead of HEAD
+	state := []string{"-C", dest, "rev-parse", "HEAD"}
+	update := []string{"-C", dest, "pull", "--ff-only"}
+	if opts.Mirror {
+		state = []string{"-C", dest, "for-each-ref"}
+		update = []string{"-C", dest, "remote", "update", "--prune"}
+	}
+
+	before, err := runGit(state...)
+	if err != nil {
+		return batchResult{name: repo.GetName(), status: batchFailed, err: err}
+	}
+	sizeBefore := gitDirSize(dest, opts.Mirror)
+	if _, err := runGit(update...); err != nil {
+		return batchResult{name: repo.GetName(), status: batchFailed, err: err}
+	}
+	bytes := max(gitDirSize(dest, opts.Mirror)-sizeBefore, 0)
+	after, err := runGit(state...)
+	if err != nil {
+		return batchResult{name: repo.GetName(), status: batchFailed, err: err, bytes: bytes}
+	}
+
+	if string(before) == string(after) {
+		return batchResult{name: repo.GetName(), status: batchUnchanged, bytes: bytes}
+	}
+	return batchResult{name: repo.GetName(), status: batchUpdated, bytes: bytes}
+}
+
+func runBatch(account string, opts BatchOptions, do func(*github.Repository, BatchOptions) batchResult, updateExisting bool) error {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+
+	repos, _, err := fetchRepos(account)
+	if err != nil {
+		return err
+	}
+	repos = selectRepos(repos, opts)
+	fmt.Printf("%d repositories selected from %s\n", len(repos), account)
+
+	if opts.DryRun {
+		printDryRun(repos, opts, updateExisting)
+		return nil
+	}
+	return runRepos(account, repos, opts, do)
+}
+
+// runRepos runs do on repos, opts.Concurrency at a time, printing progress
+// and a summary.
+func runRepos(account string, repos []*github.Repository, opts BatchOptions, do func(*github.Repository, BatchOptions) batchResult) error {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	start := time.Now()
+	results := make([]batchResult, len(repos))
+	eta := newBatchETA(repos)
+	sem := make(chan struct{}, opts.Concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for i, repo := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			repoStart := time.Now()
+			res := do(repo, opts)
+			res.took = time.Since(repoStart)
+			results[i] = res
+
+			mu.Lock()
+			defer mu.Unlock()
+			fmt.Printf("%s %s (%s%s) %s\n", res.status.symbol(), res.name, res.status, res.transfer(), eta.done(repo, time.Since(start)))
+		}()
+	}
+	wg.Wait()
+
+	took := time.Since(start)
+	err := printBatchSummary(results, took)
+	if took >= notifyAfter {
+		notify(batchNotification(account, results, took))
+	}
+	return err
+}
+
+func printDryRun(repos []*github.Repository, opts BatchOptions, updateExisting bool) {
+	var clones, pulls, skips, sizeKB int
+	for _, repo := range repos {
+		dest := cloneDest(repo, opts)
+		if _, err := os.Stat(dest); err == nil {
+			if updateExisting {
+				pulls++
+				fmt.Printf("would pull  %s -> %s\n", repo.GetName(), dest)
+			} else {
+				skips++
+				fmt.Printf("would skip  %s (%s exists)\n", repo.GetName(), dest)
+			}
+			continue
+		}
+		clones++
+		sizeKB += repo.GetSize()
+		fmt.Printf("would clone %s -> %s (~%s)\n", repo.GetName(), dest, humanSize(repo.GetSize()))
+	}
+
+	fmt.Printf("\nclone: %d, pull: %d, skip: %d, estimated download: ~%s\n", clones, pulls, skips, humanSize(sizeKB))
+}
+
+// humanSize formats a size in KiB as reported by the GitHub API.
+func humanSize(kb int) string {
+	switch {
+	case kb >= 1024*1024:
+		return fmt.Sprintf("%.1f GB", float64(kb)/(1024*1024))
+	case kb >= 1024:
+		return fmt.Sprintf("%.1f MB", float64(kb)/1024)
+	default:
+		return fmt.Sprintf("%d KB", kb)
+	}
+}
+
+// humanBytes formats a byte count, rounding down to whole KiB.
+func humanBytes(n int64) string {
+	if n == 0 {
+		return "-"
+	}
+	return humanSize(int(max(n/1024, 1)))
+}
+
+func (res batchResult) transfer() string {
+	if res.bytes == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", %s in %s", humanBytes(res.bytes), res.took.Round(100*time.Millisecond))
+}
+
+// batchETA estimates the time left from the share of the selection's size,
+// as the API reports it, that is done. Sizes are floored at 1 KiB so empty
+// repositories still count.
+type batchETA struct {
+	totalKB, doneKB int
+	count, n        int
+}
+
+func newBatchETA(repos []*github.Repository) *batchETA {
+	e := &batchETA{count: len(repos)}
+	for _, repo := range repos {
+		e.totalKB += max(repo.GetSize(), 1)
+	}
+	return e
+}
+
+// done records repo as finished after elapsed and describes the progress.
+// Callers serialize calls.
+func (e *batchETA) done(repo *github.Repository, elapsed time.Duration) string {
+	e.n++
+	e.doneKB += max(repo.GetSize(), 1)
+	progress := fmt.Sprintf("[%d/%d", e.n, e.count)
+	if e.n < e.count {
+		left := time.Duration(float64(elapsed) * float64(e.totalKB-e.doneKB) / float64(e.doneKB))
+		progress += fmt.Sprintf(", ~%s left", left.Round(time.Second))
+	}
+	return progress + "]"
+}
+
+func batchNotification(account string, results []batchResult, took time.Duration) string {
+	failed := 0
+	for _, res := range results {
+		if res.status == batchFailed {
+			failed++
+		}
+	}
+	msg := fmt.Sprintf("%d repositories of %s done in %s", len(results), account, took.Round(time.Second))
+	if failed > 0 {
+		msg += fmt.Sprintf(", %d failed", failed)
+	}
+	return msg
+}
+
+func printBatchSummary(results []batchResult, took time.Duration) error {
+	counts := make(map[batchStatus]int)
+	bytes := make(map[batchStatus]int64)
+	times := make(map[batchStatus]time.Duration)
+	var totalBytes int64
+	for _, res := range results {
+		counts[res.status]++
+		bytes[res.status] += res.bytes
+		times[res.status] += res.took
+		totalBytes += res.bytes
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\trepos\tfetched\ttime")
+	for s := batchCloned; s <= batchFailed; s++ {
+		if counts[s] == 0 && s != batchFailed {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", s, counts[s], humanBytes(bytes[s]), times[s].Round(time.Second))
+	}
+	fmt.Fprintf(w, "total\t%d\t%s\t%s\n", len(results), humanBytes(totalBytes), took.Round(time.Second))
+	fmt.Println()
+	w.Flush()
+
+	for _, res := range results {
+		if res.status == batchFailed {
+			fmt.Printf("  %s: %v\n", res.name, res.err)
+		}
+	}
+
+	if counts[batchFailed] > 0 {
+		return fmt.Errorf("%d of %d repositories failed", counts[batchFailed], len(results))
+	}
+	return nil
+}
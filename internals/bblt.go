@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
+	"time"
 
+	"github.com/arshpsps/gitls/pkg/gitls"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -14,43 +15,201 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/google/go-github/v50/github"
-	"golang.org/x/oauth2"
 )
 
 var (
 	normalStyle  = lipgloss.NewStyle().Margin(1, 2)
 	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
 	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+	badgeStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 )
 
 type item struct {
-	name string
-	url  string
+	name          string
+	url           string
+	private       bool
+	fork          bool
+	archived      bool
+	template      bool
+	pinned        bool
+	stars         int
+	language      string
+	updated       time.Time
+	description   string
+	size          int // KiB
+	owner         string
+	defaultBranch string
+	lfs           bool
+	note          string
+	topics        []string
+	license       string // SPDX id
+	fullName      string
+	pushed        time.Time
+	fresh         freshness
+	showOwner     bool // lists mixing owners show owner/name
+	gist          bool
+	source        string // owned, starred or gist in the everything view
 }
 
-func (i item) Title() string       { return i.name }
-func (i item) Description() string { return i.url }
-func (i item) FilterValue() string { return i.name }
+func (i item) Title() string {
+	var badges []string
+	switch i.fresh {
+	case freshNew:
+		badges = append(badges, successStyle.Render(tr("new")))
+	case freshPushed:
+		badges = append(badges, successStyle.Render(tr("pushed")))
+	}
+	if i.pinned {
+		badges = append(badges, glyph("📌", "[pinned]"))
+	}
+	if i.private {
+		badges = append(badges, glyph("🔒", "[private]"))
+	}
+	if i.fork {
+		badges = append(badges, glyph("⑂", "[fork]"))
+	}
+	if i.archived {
+		badges = append(badges, badgeStyle.Render(tr("archived")))
+	}
+	if i.template {
+		badges = append(badges, badgeStyle.Render(tr("template")))
+	}
+	if i.source != "" {
+		badges = append(badges, badgeStyle.Render(i.source))
+	}
+	name := i.name
+	if i.showOwner && i.owner != "" {
+		name = i.owner + "/" + i.name
+	}
+	if len(badges) == 0 {
+		return name
+	}
+	return name + " " + strings.Join(badges, " ")
+}
+
+func (i item) Description() string {
+	parts := []string{fmt.Sprintf("%s %d", glyph("★", "stars"), i.stars)}
+	if i.language != "" {
+		parts = append(parts, i.language)
+	}
+	if e, ok := enrichmentFor(i); ok && e.release != "" {
+		parts = append(parts, e.release)
+	}
+	if !i.updated.IsZero() {
+		parts = append(parts, tr("updated %s", relativeTime(i.updated)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func relativeTime(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d.Minutes()), "minute") + " ago"
+	case d < 24*time.Hour:
+		return plural(int(d.Hours()), "hour") + " ago"
+	case d < 30*24*time.Hour:
+		return plural(int(d.Hours()/24), "day") + " ago"
+	case d < 365*24*time.Hour:
+		return plural(int(d.Hours()/(24*30)), "month") + " ago"
+	default:
+		return plural(int(d.Hours()/(24*365)), "year") + " ago"
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// FilterValue adds the note, so notes are searchable; a gist's files, since
+// its name is only an id; and the source in the everything view, so typing
+// "gist" narrows to gists.
+func (i item) FilterValue() string {
+	value := i.name
+	if i.showOwner && i.owner != "" {
+		value = i.owner + "/" + i.name
+	}
+	if i.note != "" {
+		value += " " + i.note
+	}
+	if i.gist {
+		value += " " + i.description
+	}
+	if i.source != "" {
+		value += " " + i.source
+	}
+	return value
+}
 
 type repoModel struct {
-	username   string
-	repos      []*github.Repository
-	list       list.Model
-	err        error
-	spinner    spinner.Model
-	cloning    bool
-	cloneMsg   string
-	cloneError bool
+	username string
+	repos    []*github.Repository
+	info     fetchInfo
+	sort     sortMode
+	width    int
+	height   int
+	list     list.Model
+	confirm  *item // large clone awaiting confirmation
+	conflict *destConflict
+	partial  bool
+
+	lastClick     clickState
+	detailOffset  int
+	hidePreview   bool // detail pane toggled off with |
+	split         int  // list share of the screen in percent, see splitRatio
+	vim           vimState
+	crumb         string // name in the breadcrumb header, see crumbs
+	editingNote   bool
+	noteInput     textinput.Model
+	exporting     bool
+	exportInput   textinput.Model
+	scaffolding   bool
+	scaffoldInput textinput.Model
+	lastClone     string // absolute path of the last successful clone
+	cloneRef      string // tag picked with r for the next clone
+	compareWith   *item  // first repository marked with m
+	confirmUndo   bool
+	bootstrapRun  []string          // bootstrap commands waiting for confirmation
+	upstreamFor   *cloneFinishedMsg // fork waiting for the upstream prompt
+	spinner       spinner.Model
+	cloning       bool
+	cloneMsg      string
+	cloneError    bool
+
+	// affiliation limits your own listing, see cycleAffiliation
+	affiliation string
+
+	// filter hides repositories by attribute, see setItems
+	filter     repoFilter
+	restore    *uiState // last session's state, applied on the first resize
+	hidden     []list.Item
+	attrPrompt string // attribute being entered, see promptAttr
+	attrInput  textinput.Model
+
+	// watch enables periodic refreshes of an account listing
+	watch       bool
+	nextRefresh time.Time
+	rateTicked  time.Time // last countdown tick while served from cache
 }
 
 type usernameModel struct {
-	rootModel repoModel
 	username  string
 	textInput textinput.Model
 	err       error
+
+	// checking is set while the entered login is looked up, see checkUser
+	checking    bool
+	notFound    string   // login that doesn't exist
+	suggestions []string // similar logins
+	suggestion  int      // 0 for the typed login, else suggestions[n-1]
 }
 
-func prepUsernameModel(username string, rootModel repoModel) usernameModel {
+func prepUsernameModel(username string) usernameModel {
 	ti := textinput.New()
 	ti.SetValue(username)
 	ti.Focus()
@@ -58,14 +217,26 @@ func prepUsernameModel(username string, rootModel repoModel) usernameModel {
 	ti.CharLimit = 64
 
 	return usernameModel{
-		rootModel: rootModel,
 		username:  username,
 		textInput: ti,
 		err:       nil,
 	}
 }
 
+// lookupUsernameModel is the picker already looking username up, as if it
+// had been typed in and entered.
+func lookupUsernameModel(username string) usernameModel {
+	m := prepUsernameModel(username)
+	m.checking = true
+	return m
+}
+
+func (m usernameModel) crumbs() []string { return []string{"username"} }
+
 func (m usernameModel) Init() tea.Cmd {
+	if m.checking {
+		return tea.Batch(textinput.Blink, checkUser(strings.TrimSpace(m.textInput.Value())))
+	}
 	return textinput.Blink
 }
 
@@ -80,19 +251,35 @@ func (m usernameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case tea.KeyEnter:
 			username := strings.TrimSpace(m.textInput.Value())
-			if username == "" {
+			if username == "" || m.checking {
 				return m, nil
 			}
-			return initialModel(username), nil
+			m.checking = true
+			return m, checkUser(username)
 
-		case tea.KeyEsc:
-			if m.username == "" {
-				return m, tea.Quit
-			} else {
-				return m.rootModel, nil
+		case tea.KeyUp, tea.KeyDown:
+			if next, ok := m.updateSuggestions(msg); ok {
+				return next, nil
+			}
+
+		case tea.KeyCtrlF:
+			// search without listing everything first
+			username := strings.TrimSpace(m.textInput.Value())
+			if username == "" {
+				return m, nil
 			}
+			return m, navigate(newSearchModel(username))
 
+		case tea.KeyEsc:
+			return m, goBack
 		}
+	case userCheckedMsg:
+		m.checking = false
+		if !msg.notFound {
+			return initialModel(msg.username), nil
+		}
+		m.notFound, m.suggestions, m.suggestion = msg.username, msg.suggestions, 0
+		return m, nil
 	}
 
 	m.textInput, cmd = m.textInput.Update(msg)
@@ -100,68 +287,448 @@ func (m usernameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m usernameModel) View() string {
+	status := m.suggestionsView()
+	if m.checking {
+		status = tr("Looking up %s...", strings.TrimSpace(m.textInput.Value()))
+	}
+	if status != "" {
+		status = "\n" + status + "\n"
+	}
 	return fmt.Sprintf(
-		"What’s your Github Username?\n%s\n\n%s",
+		"%s\n%s\n%s\n(%s)",
+		tr("What’s your Github Username?"),
 		m.textInput.View(),
-		"(esc to quit)",
+		status,
+		tr("enter: list repositories · ctrl+f: search them · esc: back"),
 	) + "\n"
 }
 
 type cloneFinishedMsg struct {
 	err error
-	dir string
+	dir string // absolute
+	// target is where the clone was going, also set when it failed.
+	target string
+	repo   string // owner/name
+	url    string
+	fork   bool
+	// template is a template repository, which x scaffolds into a project
+	template bool
+	opts     gitls.CloneOptions
+	sparse   []string // sparse checkout directories
+	// hookErr is a failed post-clone hook; the clone itself succeeded.
+	hookErr error
+}
+
+// cloneTarget is the absolute directory to clone it into. It is built from
+// the owner and name the API reports rather than the clone URL, which may
+// be SSH, lack a .git suffix or be rewritten.
+func cloneTarget(it item) string {
+	return absCloneDir(gitls.Dest(gitls.Layout(DefaultLayout()), DefaultCloneDir(), it.url, it.owner, it.name))
 }
 
-func cloneRepo(url string) tea.Cmd {
+// cloneRepo clones it into dest, first moving whatever is there to the
+// trash when replace is set. It comes back if the clone fails.
+func cloneRepo(it item, dest string, replace bool, opts gitls.CloneOptions) tea.Cmd {
 	return func() tea.Msg {
-		cmd := exec.Command("git", "clone", url)
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return cloneFinishedMsg{
-				err: fmt.Errorf("%w: %s", err, string(output)),
-				dir: "",
+		msg := cloneFinishedMsg{repo: it.owner + "/" + it.name, url: it.url, fork: it.fork, template: it.template, target: dest, opts: opts}
+		if err := runHook(newHookPayload(hookPreClone, it, dest)); err != nil {
+			msg.err = err
+			return msg
+		}
+		trashed := ""
+		if replace {
+			id, err := moveToTrash(dest)
+			if err != nil {
+				msg.err = err
+				return msg
 			}
+			trashed = id
 		}
-		return cloneFinishedMsg{
-			err: nil,
-			dir: url[strings.LastIndex(url, "/")+1 : len(url)-4], // crazy url parsing
+		if err := gitRunner().Clone(context.Background(), it.url, dest, opts); err != nil {
+			msg.err = err
+			if trashed != "" {
+				// put back what was there rather than leave nothing
+				os.RemoveAll(dest)
+				if rerr := untrash(trashed, dest); rerr != nil {
+					msg.err = fmt.Errorf("%w; %s stays in the trash as %s: %v", err, dest, trashed, rerr)
+				}
+			}
+			return msg
 		}
+		msg.dir = dest
+		msg.hookErr = runHook(newHookPayload(hookPostClone, it, dest))
+		return msg
 	}
 }
 
+// crumbs names the list by its account unless it was given a crumb, e.g.
+// favorites or a search.
+func (m repoModel) crumbs() []string {
+	switch {
+	case m.crumb != "":
+		return []string{m.crumb}
+	case m.username != "":
+		return []string{m.username}
+	}
+	return []string{m.list.Title}
+}
+
 func (m repoModel) Init() tea.Cmd {
-	return m.spinner.Tick
+	return tea.Batch(m.spinner.Tick, m.setWindowTitle())
 }
 
 func (m repoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	rm, ok := model.(repoModel)
+	if !ok {
+		return model, cmd
+	}
+	recordListChange(m, rm)
+	if rm.watchStalled() {
+		cmd = tea.Batch(cmd, rm.scheduleRefresh())
+	}
+	return rm, tea.Batch(cmd, rm.wantRateTick())
+}
+
+func (m repoModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" && !m.cloning {
 			return m, tea.Quit
 		}
-		if msg.String() == "enter" && !m.cloning {
-			selectedItem := m.list.SelectedItem().(item)
+		if m.confirm != nil {
+			return m.updateConfirm(msg)
+		}
+		if m.conflict != nil {
+			return m.updateConflict(msg)
+		}
+		if m.editingNote {
+			return m.updateNote(msg)
+		}
+		if m.exporting {
+			return m.updateExport(msg)
+		}
+		if m.scaffolding {
+			return m.updateScaffold(msg)
+		}
+		if m.attrPrompt != "" {
+			return m.updateAttrPrompt(msg)
+		}
+		if m.confirmUndo {
+			return m.updateUndo(msg)
+		}
+		if m.bootstrapRun != nil {
+			return m.updateBootstrapConfirm(msg)
+		}
+		if m.upstreamFor != nil {
+			return m.updateUpstream(msg)
+		}
+		if m.vim.enabled && !m.cloning {
+			if model, cmd, handled := m.updateVim(msg); handled {
+				return model, cmd
+			}
+		}
+		if msg.String() == "enter" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.cloneSelected()
+		}
+		if msg.String() == "c" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, navigate(prepUsernameModel(m.username))
+		}
+		if msg.String() == "s" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, m.setSort(m.sort.next())
+		}
+		if msg.String() == "S" && !m.cloning && m.list.FilterState() != list.Filtering {
+			selectedItem, ok := m.list.SelectedItem().(item)
+			if !ok {
+				return m, nil
+			}
+			return m, navigate(newSparseModel(selectedItem))
+		}
+		if msg.String() == "r" && !m.cloning && m.list.FilterState() != list.Filtering {
+			selectedItem, ok := m.list.SelectedItem().(item)
+			if !ok {
+				return m, nil
+			}
+			return m, navigate(newTagsModel(selectedItem))
+		}
+		if msg.String() == "m" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.markCompare()
+		}
+		if msg.String() == "D" && !m.cloning && m.list.FilterState() != list.Filtering {
+			selectedItem, ok := m.list.SelectedItem().(item)
+			if !ok {
+				return m, nil
+			}
 			m.cloning = true
-			m.cloneMsg = fmt.Sprintf("Cloning %s...", selectedItem.name)
-			return m, tea.Batch(
-				m.spinner.Tick,
-				cloneRepo(selectedItem.url),
-			)
+			m.cloneMsg = tr("Downloading %s...", selectedItem.name)
+			return m, tea.Batch(m.spinner.Tick, downloadArchive(selectedItem))
+		}
+		if m.list.FilterState() != list.Filtering {
+			if model, cmd, handled := m.updateOpen(msg); handled {
+				return model, cmd
+			}
+		}
+		if msg.String() == "b" && m.lastClone != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.startBootstrap()
+		}
+		if msg.String() == "x" && m.lastClone != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.startScaffold()
+		}
+		if msg.String() == "u" && m.lastClone != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			m.confirmUndo = true
+			return m, nil
+		}
+		if msg.String() == "i" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, navigate(newStatsModel(m.list.Title, m.list.Items()))
+		}
+		if msg.String() == "a" && m.username != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, navigate(newEventsModel(m.username))
+		}
+		if msg.String() == "N" && m.info.login != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, navigate(newNotificationsModel(m.username))
+		}
+		if msg.String() == "V" && m.info.login != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			selectedItem, ok := m.list.SelectedItem().(item)
+			if !ok {
+				return m, nil
+			}
+			return m, navigate(newTrafficModel(selectedItem))
+		}
+		if msg.String() == "W" && m.info.login != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			selectedItem, ok := m.list.SelectedItem().(item)
+			if !ok {
+				return m, nil
+			}
+			return m, navigate(newWebhooksModel(selectedItem))
+		}
+		if msg.String() == "K" && m.info.login != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			selectedItem, ok := m.list.SelectedItem().(item)
+			if !ok {
+				return m, nil
+			}
+			return m, navigate(newKeysModel(selectedItem))
+		}
+		if msg.String() == "C" && !m.cloning && m.list.FilterState() != list.Filtering {
+			selectedItem, ok := m.list.SelectedItem().(item)
+			if !ok {
+				return m, nil
+			}
+			return m, navigate(newContributorsModel(selectedItem))
+		}
+		if msg.String() == "A" && m.ownAccount() && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.cycleAffiliation()
+		}
+		if msg.String() == "v" && m.info.login != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.cycleVisibility()
+		}
+		if msg.String() == "R" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.cycleActivity()
+		}
+		if msg.String() == "#" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.promptAttr("topic", m.filter.topic, m.topics())
+		}
+		if msg.String() == "L" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.promptAttr("license", m.filter.license, m.licenses())
+		}
+		if msg.String() == "t" && m.info.login != "" && m.username != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, navigate(newTeamsModel(m.username, m.info))
+		}
+		if msg.String() == "T" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, navigate(newTrendingModel())
+		}
+		if msg.String() == "H" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, navigate(newHistoryModel())
+		}
+		if msg.String() == "E" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.startExport()
+		}
+		if msg.String() == "n" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.editNote()
+		}
+		if msg.String() == "*" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m.togglePinSelected()
+		}
+		if msg.String() == "F" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, navigate(favoritesModel())
+		}
+		if msg.String() == "B" && m.username != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			m.cloneError = false
+			m.cloneMsg = tr("Fetching starred repositories…")
+			return m, starredList(m.username)
+		}
+		if msg.String() == "e" && m.username != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			m.cloneError = false
+			m.cloneMsg = tr("Fetching repositories, stars and gists…")
+			return m, everythingList(m.username)
+		}
+		if msg.String() == "X" && m.username != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			m.cloneError = false
+			m.cloneMsg = tr("Fetching gists…")
+			return m, gistList(m.username)
+		}
+		if msg.String() == "ctrl+f" && m.username != "" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, navigate(newSearchModel(m.username))
+		}
+		if msg.String() == "esc" && m.list.FilterState() == list.Unfiltered {
+			return m, goBack
+		}
+		if msg.String() == "P" && !m.cloning && m.list.FilterState() != list.Filtering {
+			return m, navigate(newProfileModel(m.username))
+		}
+		if msg.String() == "|" && m.list.FilterState() != list.Filtering {
+			m.hidePreview = !m.hidePreview
+			m.resize()
+			return m, m.wantCIStatus()
+		}
+		if (msg.String() == "<" || msg.String() == ">") && m.list.FilterState() != list.Filtering {
+			if msg.String() == "<" {
+				m.resizeSplit(-splitStep)
+			} else {
+				m.resizeSplit(splitStep)
+			}
+			return m, nil
+		}
+		if msg.String() == "p" && !m.cloning && m.list.FilterState() != list.Filtering {
+			m.partial = !m.partial
+			return m, nil
 		}
-		if msg.String() == "c" && !m.cloning {
-			return prepUsernameModel(m.username, m), nil
+	case tea.MouseMsg:
+		if m.cloning || m.confirm != nil || m.conflict != nil {
+			return m, nil
 		}
+		if m.inDetail(msg) {
+			switch msg.Button {
+			case tea.MouseButtonWheelUp:
+				m.detailOffset = max(m.detailOffset-1, 0)
+			case tea.MouseButtonWheelDown:
+				m.detailOffset++
+			}
+			return m, nil
+		}
+		prev := m.list.GlobalIndex()
+		double := handleListMouse(&m.list, &m.lastClick, msg)
+		var cmd tea.Cmd
+		if m.list.GlobalIndex() != prev {
+			m.detailOffset = 0
+			cmd = m.selectionChanged()
+		}
+		if double {
+			return m.cloneSelected()
+		}
+		return m, cmd
 	case tea.WindowSizeMsg:
-		h, v := normalStyle.GetFrameSize()
-		m.list.SetSize(msg.Width-h, msg.Height-v)
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resize()
+		if m.restore != nil {
+			return m, m.applyUIState()
+		}
+		return m, tea.Batch(m.wantCIStatus(), m.wantEnrich())
+	case ciWantedMsg:
+		return m, m.fetchCIStatus(msg)
+	case ciFetchedMsg:
+		return m, nil
+	case enrichedMsg:
+		return m, nil
+	case rateTickMsg:
+		return m.rateTick()
+	case selectHookMsg:
+		return m, m.runSelectHook(msg)
+	case lfsCheckedMsg:
+		m.cloning = false
+		m.cloneMsg = ""
+		it := msg.it
+		it.lfs = msg.lfs
+		if it.lfs || (cfg.LargeRepoMB > 0 && it.size > cfg.LargeRepoMB*1024) {
+			m.confirm = &it
+			return m, nil
+		}
+		return m.startClone(it, gitls.CloneOptions{})
 	case cloneFinishedMsg:
+		m.cloning = false
+		recordClone(msg.repo, msg.url, msg.dir, msg.err)
+		if msg.err != nil {
+			m.cloneError = true
+			m.cloneMsg = tr("Error cloning: %s", gitFailure(msg.err, msg.url, msg.target))
+		} else {
+			recordSessionClone(msg.repo, msg.url, msg.dir, msg.opts, msg.sparse)
+			m.cloneError = false
+			m.lastClone = msg.dir
+			actions := cloneActions(msg.dir)
+			if msg.template {
+				actions += " · " + tr("x: scaffold")
+			}
+			m.cloneMsg = tr("Successfully cloned to %s/ (%s)", msg.dir, actions)
+			if msg.hookErr != nil {
+				m.cloneError = true
+				m.cloneMsg = tr("Cloned to %s/, but %v", msg.dir, msg.hookErr)
+			}
+			if msg.fork {
+				return m.offerUpstream(msg)
+			}
+		}
+		return m, nil
+	case scaffoldDoneMsg:
+		return m.scaffoldDone(msg)
+	case bootstrapEndedMsg:
+		return m.bootstrapEnded(msg)
+	case sparseCloneMsg:
+		m.cloning = true
+		m.cloneMsg = tr("Sparse cloning %s (%s)...", msg.it.name, strings.Join(msg.paths, ", "))
+		return m, tea.Batch(m.spinner.Tick, cloneSparse(msg.it, msg.paths))
+	case cloneAtTagMsg:
+		m.cloneRef = msg.tag
+		return m.cloneSelected()
+	case selectRepoMsg:
+		if !m.selectByName(msg.name) {
+			m.cloneError = true
+			m.cloneMsg = tr("%s is not in this list", msg.name)
+		}
+		return m, nil
+	case archiveFinishedMsg:
 		m.cloning = false
 		if msg.err != nil {
 			m.cloneError = true
-			m.cloneMsg = fmt.Sprintf("Error cloning: %v", msg.err)
+			m.cloneMsg = tr("Error downloading: %v", msg.err)
 		} else {
 			m.cloneError = false
-			m.cloneMsg = fmt.Sprintf("Successfully cloned to %s/", msg.dir)
+			m.lastClone = msg.dir
+			m.cloneMsg = tr("Downloaded source to %s/ (%s)", msg.dir, cloneActions(msg.dir))
+		}
+		return m, nil
+	case refreshTickMsg:
+		return m, m.refreshRepos()
+	case reposFilteredMsg:
+		return m.applyAffiliation(msg)
+	case reposRefreshedMsg:
+		return m.applyRefresh(msg)
+	case openFinishedMsg:
+		if msg.err != nil {
+			m.cloneError = true
+			m.cloneMsg = tr("Error opening: %v", msg.err)
+		} else {
+			m.cloneError = false
+			m.cloneMsg = msg.status
+		}
+		return m, nil
+	case otherListMsg:
+		return m.openOtherList(msg)
+	case upstreamAddedMsg:
+		if msg.err != nil {
+			m.cloneError = true
+			m.cloneMsg = tr("Error adding upstream: %v", msg.err)
+		} else {
+			m.cloneError = false
+			m.cloneMsg = tr("Added %s as upstream of %s/", msg.parent, msg.dir)
+		}
+		return m, nil
+	case undoFinishedMsg:
+		if msg.err != nil {
+			m.cloneError = true
+			m.cloneMsg = tr("Error removing %s: %v", msg.dir, msg.err)
+		} else {
+			m.cloneError = false
+			m.cloneMsg = tr("Moved %s to the trash; gitls trash restore %s brings it back", msg.dir, msg.id)
 		}
 		return m, nil
 	case spinner.TickMsg:
@@ -170,87 +737,277 @@ func (m repoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	prev := m.list.GlobalIndex()
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
-	return m, cmd
+	if m.list.GlobalIndex() != prev {
+		m.detailOffset = 0
+		cmd = tea.Batch(cmd, m.selectionChanged())
+	}
+	return m, tea.Batch(cmd, m.wantEnrich())
 }
 
-func (m repoModel) View() string {
-	if m.err != nil {
-		return errorStyle.Render(fmt.Sprintf("Error fetching repos: %v\nPress any key to exit", m.err))
+func (m *repoModel) resize() {
+	h, v := normalStyle.GetFrameSize()
+	w, height := m.width-h, m.height-v-1
+	switch m.layout() {
+	case layoutSplit:
+		w = w * m.splitRatio() / 100
+	case layoutStacked:
+		height = height * m.splitRatio() / 100
 	}
+	m.list.SetSize(w, height)
+}
 
-	if m.cloning {
-		return normalStyle.Render(
-			lipgloss.JoinVertical(
-				lipgloss.Left,
-				m.list.View(),
-				"\n"+m.spinner.View()+" "+m.cloneMsg,
-			),
+func (m *repoModel) setSort(mode sortMode) tea.Cmd {
+	m.sort = mode
+	return m.setItems(m.allItems())
+}
+
+func (m repoModel) togglePinSelected() (tea.Model, tea.Cmd) {
+	it, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return m, nil
+	}
+	pinned, err := togglePin(it)
+	if err != nil {
+		m.cloneError = true
+		m.cloneMsg = tr("Error saving pins: %v", err)
+		return m, nil
+	}
+	it.pinned = pinned
+	m.list.SetItem(m.list.GlobalIndex(), it)
+	return m, m.setSort(m.sort)
+}
+
+func (m repoModel) cloneSelected() (tea.Model, tea.Cmd) {
+	if pickMode != "" {
+		return m.pick()
+	}
+	selectedItem, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return m, nil
+	}
+	m.cloning = true
+	m.cloneMsg = tr("Checking %s for Git LFS...", selectedItem.name)
+	return m, tea.Batch(m.spinner.Tick, checkLFS(selectedItem))
+}
+
+func (m repoModel) startClone(it item, opts gitls.CloneOptions) (tea.Model, tea.Cmd) {
+	m.confirm = nil
+	if m.partial {
+		opts.Filter = cfg.CloneFilter
+	}
+	if m.cloneRef != "" {
+		opts.Branch = m.cloneRef
+		m.cloneRef = ""
+	}
+	dest := cloneTarget(it)
+	reason, cloned := checkDest(it, dest)
+	switch {
+	case cloned:
+		m.cloning = false
+		m.cloneError = false
+		m.cloneMsg = tr("%s is already cloned in %s/", it.name, dest)
+		return m, nil
+	case reason != "":
+		m.cloning = false
+		m.conflict = &destConflict{it: it, opts: opts, dest: dest, reason: reason}
+		return m, nil
+	}
+	return m.cloneInto(it, dest, false, opts)
+}
+
+func (m repoModel) cloneInto(it item, dest string, replace bool, opts gitls.CloneOptions) (tea.Model, tea.Cmd) {
+	m.cloning = true
+	m.cloneMsg = tr("Cloning %s...", it.name)
+	if opts.Branch != "" {
+		m.cloneMsg = tr("Cloning %s at %s...", it.name, opts.Branch)
+	}
+	return m, tea.Batch(
+		m.spinner.Tick,
+		cloneRepo(it, dest, replace, opts),
+	)
+}
+
+func (m repoModel) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		return m.startClone(*m.confirm, gitls.CloneOptions{})
+	case "s":
+		return m.startClone(*m.confirm, gitls.CloneOptions{Depth: 1})
+	case "p":
+		return m.startClone(*m.confirm, gitls.CloneOptions{Filter: cfg.CloneFilter})
+	case "k":
+		if m.confirm.lfs {
+			return m.startClone(*m.confirm, gitls.CloneOptions{Env: []string{"GIT_LFS_SKIP_SMUDGE=1"}})
+		}
+	case "n", "esc":
+		m.confirm = nil
+		m.cloneRef = ""
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m repoModel) confirmView() string {
+	it := m.confirm
+	var warnings []string
+	if cfg.LargeRepoMB > 0 && it.size > cfg.LargeRepoMB*1024 {
+		warnings = append(warnings, tr("%s is %s.", it.name, humanSize(it.size)))
+	}
+	if it.lfs {
+		warnings = append(warnings, tr("%s uses Git LFS; downloading LFS objects counts against the owner's bandwidth quota.", it.name))
+	}
+
+	options := tr("y: clone · s: shallow (--depth 1) · p: partial (--filter=%s)", cfg.CloneFilter)
+	if it.lfs {
+		options += " · " + tr("k: skip LFS files (GIT_LFS_SKIP_SMUDGE=1)")
+	}
+	options += " · " + tr("n: cancel")
+
+	return errorStyle.Render(strings.Join(warnings, " ")+" Clone anyway?") + "\n" + options
+}
+
+func (m repoModel) View() string {
+	body := m.list.View()
+	h, v := normalStyle.GetFrameSize()
+	switch layout := m.layout(); {
+	case m.emptyAccount():
+		body = m.emptyView()
+	case layout == layoutSplit:
+		body = lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			body,
+			" ",
+			m.detailView(m.width-h-m.list.Width()-1, m.height-v-1),
+		)
+	case layout == layoutStacked:
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			body,
+			m.detailView(m.width-h, m.height-v-1-m.list.Height()),
 		)
 	}
 
-	if m.cloneMsg != "" {
+	if m.editingNote {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, m.noteInput.View())
+	}
+	if m.exporting {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, m.exportInput.View())
+	}
+	if m.scaffolding {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, m.scaffoldInput.View())
+	}
+	if m.attrPrompt != "" {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, m.attrInput.View())
+	}
+	if line := m.vimView(); line != "" {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, line)
+	}
+
+	if m.confirm != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, "\n"+m.confirmView())
+	} else if m.conflict != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, "\n"+m.conflictView())
+	} else if m.confirmUndo {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, "\n"+m.undoView())
+	} else if m.bootstrapRun != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, "\n"+m.bootstrapConfirmView())
+	} else if m.upstreamFor != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, "\n"+m.upstreamView())
+	} else if m.cloning {
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			body,
+			"\n"+m.spinner.View()+" "+m.cloneMsg,
+		)
+	} else if m.cloneMsg != "" {
 		style := successStyle
 		if m.cloneError {
 			style = errorStyle
 		}
-		return normalStyle.Render(
-			lipgloss.JoinVertical(
-				lipgloss.Left,
-				m.list.View(),
-				"\n"+style.Render(m.cloneMsg),
-			),
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			body,
+			"\n"+style.Render(m.cloneMsg),
 		)
 	}
 
-	return normalStyle.Render(m.list.View())
+	return lipgloss.JoinVertical(lipgloss.Left, normalStyle.Render(body), m.statusBar())
 }
 
 func initialModel(username string) tea.Model {
-	sp := spinner.New()
-	sp.Spinner = spinner.Dot
-	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-
-	repos, err := fetchRepos(username)
+	repos, info, err := fetchRepos(username)
 	if err != nil {
-		return repoModel{
-			username: username,
-			err:      err,
-			spinner:  sp,
-			list:     list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0),
-		}
-	}
-
-	if len(repos) <= 0 {
-		return repoModel{
-			username: username,
-			err:      err,
-			spinner:  sp,
-			list:     list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0),
-		}
+		return newErrorModel(username, err)
 	}
 
 	items := make([]list.Item, len(repos))
 	for i, repo := range repos {
-		items[i] = item{
-			name: repo.GetName(),
-			url:  repo.GetCloneURL(),
-		}
+		items[i] = newItem(repo)
+	}
+	sortItems(items, sortByName)
+
+	m := newRepoModel(tr("%s's GitHub Repositories", username), items)
+	m.username = username
+	m.repos = repos
+	m.info = info
+	m.watch = true
+	return m
+}
+
+func newItem(repo *github.Repository) item {
+	it := item{
+		name:     repo.GetName(),
+		url:      cloneURL(repo),
+		private:  repo.GetPrivate(),
+		fork:     repo.GetFork(),
+		archived: repo.GetArchived(),
+		template: repo.GetIsTemplate(),
+		stars:    repo.GetStargazersCount(),
+		language: repo.GetLanguage(),
+		updated:  repo.GetUpdatedAt().Time,
+
+		description:   repo.GetDescription(),
+		size:          repo.GetSize(),
+		owner:         repo.GetOwner().GetLogin(),
+		topics:        repo.Topics,
+		license:       repo.GetLicense().GetSPDXID(),
+		fullName:      repo.GetFullName(),
+		pushed:        repo.GetPushedAt().Time,
+		defaultBranch: repo.GetDefaultBranch(),
 	}
+	it.pinned = isPinned(it)
+	it.note = noteFor(it)
+	return it
+}
 
+func newRepoModel(title string, items []list.Item) repoModel {
 	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
-	l.Title = username + "'s GitHub Repositories"
+	localizeList(&l)
+	// b bootstraps and u undoes the last clone, so they no longer page
+	l.KeyMap.PrevPage = key.NewBinding(key.WithKeys("left", "h", "pgup"), key.WithHelp("←/h/pgup", tr("prev page")))
+	l.Title = title
 
 	l.AdditionalShortHelpKeys = func() []key.Binding {
 		return []key.Binding{
 			key.NewBinding(
 				key.WithKeys("enter"),
-				key.WithHelp("enter", "clone repo"),
+				key.WithHelp("enter", tr("clone repo")),
 			),
 			key.NewBinding(
 				key.WithKeys("c"),
-				key.WithHelp("c", "change user"),
+				key.WithHelp("c", tr("change user")),
+			),
+			key.NewBinding(
+				key.WithKeys("s"),
+				key.WithHelp("s", tr("sort")),
+			),
+			key.NewBinding(
+				key.WithKeys("p"),
+				key.WithHelp("p", tr("partial clone")),
 			),
 		}
 	}
@@ -259,73 +1016,243 @@ func initialModel(username string) tea.Model {
 		return []key.Binding{
 			key.NewBinding(
 				key.WithKeys("enter"),
-				key.WithHelp("enter", "clone selected repository"),
+				key.WithHelp("enter", tr("clone selected repository")),
 			),
 			key.NewBinding(
 				key.WithKeys("c"),
-				key.WithHelp("c", "change GitHub username"),
+				key.WithHelp("c", tr("change GitHub username")),
+			),
+			key.NewBinding(
+				key.WithKeys("s"),
+				key.WithHelp("s", tr("cycle sort (name, stars, updated)")),
+			),
+			key.NewBinding(
+				key.WithKeys("p"),
+				key.WithHelp("p", tr("toggle partial clone (--filter)")),
+			),
+			key.NewBinding(
+				key.WithKeys("S"),
+				key.WithHelp("S", tr("sparse clone selected directories")),
+			),
+			key.NewBinding(
+				key.WithKeys("r"),
+				key.WithHelp("r", tr("clone at a release tag")),
+			),
+			key.NewBinding(
+				key.WithKeys("m"),
+				key.WithHelp("m", tr("mark two repositories to compare")),
+			),
+			key.NewBinding(
+				key.WithKeys("D"),
+				key.WithHelp("D", tr("download source without git")),
+			),
+			key.NewBinding(
+				key.WithKeys("P"),
+				key.WithHelp("P", tr("switch profile")),
+			),
+			key.NewBinding(
+				key.WithKeys("*"),
+				key.WithHelp("*", tr("pin/unpin repository")),
+			),
+			key.NewBinding(
+				key.WithKeys("n"),
+				key.WithHelp("n", tr("edit note")),
+			),
+			key.NewBinding(
+				key.WithKeys("E"),
+				key.WithHelp("E", tr("export visible list (json/csv/urls)")),
+			),
+			key.NewBinding(
+				key.WithKeys("|"),
+				key.WithHelp("|", tr("toggle preview")),
+			),
+			key.NewBinding(
+				key.WithKeys("<", ">"),
+				key.WithHelp("</>", tr("resize preview")),
+			),
+			key.NewBinding(
+				key.WithKeys("u"),
+				key.WithHelp("u", tr("undo last clone")),
+			),
+			key.NewBinding(
+				key.WithKeys("o", "O"),
+				key.WithHelp("o/O", tr("open last clone in editor/file manager")),
+			),
+			key.NewBinding(
+				key.WithKeys("y"),
+				key.WithHelp("y", tr("copy last clone path")),
+			),
+			key.NewBinding(
+				key.WithKeys("b"),
+				key.WithHelp("b", tr("bootstrap last clone")),
+			),
+			key.NewBinding(
+				key.WithKeys("x"),
+				key.WithHelp("x", tr("scaffold last clone")),
+			),
+			key.NewBinding(
+				key.WithKeys("a"),
+				key.WithHelp("a", tr("activity feed")),
+			),
+			key.NewBinding(
+				key.WithKeys("C"),
+				key.WithHelp("C", tr("contributors")),
+			),
+			key.NewBinding(
+				key.WithKeys("V"),
+				key.WithHelp("V", tr("traffic (views and clones)")),
+			),
+			key.NewBinding(
+				key.WithKeys("W"),
+				key.WithHelp("W", tr("manage webhooks")),
+			),
+			key.NewBinding(
+				key.WithKeys("K"),
+				key.WithHelp("K", tr("deploy and SSH keys")),
+			),
+			key.NewBinding(
+				key.WithKeys("N"),
+				key.WithHelp("N", tr("notifications")),
+			),
+			key.NewBinding(
+				key.WithKeys("A"),
+				key.WithHelp("A", tr("filter own repos by affiliation")),
+			),
+			key.NewBinding(
+				key.WithKeys("v"),
+				key.WithHelp("v", tr("show private/public only")),
+			),
+			key.NewBinding(
+				key.WithKeys("R"),
+				key.WithHelp("R", tr("filter by last push (week, month, year, older)")),
+			),
+			key.NewBinding(
+				key.WithKeys("#"),
+				key.WithHelp("#", tr("filter by topic")),
+			),
+			key.NewBinding(
+				key.WithKeys("L"),
+				key.WithHelp("L", tr("filter by license")),
+			),
+			key.NewBinding(
+				key.WithKeys("t"),
+				key.WithHelp("t", tr("organization teams")),
+			),
+			key.NewBinding(
+				key.WithKeys("T"),
+				key.WithHelp("T", tr("trending repositories")),
+			),
+			key.NewBinding(
+				key.WithKeys("B"),
+				key.WithHelp("B", tr("starred repositories")),
+			),
+			key.NewBinding(
+				key.WithKeys("X"),
+				key.WithHelp("X", tr("gists")),
+			),
+			key.NewBinding(
+				key.WithKeys("e"),
+				key.WithHelp("e", tr("everything: repos, stars and gists")),
+			),
+			key.NewBinding(
+				key.WithKeys("H"),
+				key.WithHelp("H", tr("clone history")),
+			),
+			key.NewBinding(
+				key.WithKeys("i"),
+				key.WithHelp("i", tr("account statistics")),
+			),
+			key.NewBinding(
+				key.WithKeys("F"),
+				key.WithHelp("F", tr("favorites across users")),
+			),
+			key.NewBinding(
+				key.WithKeys("ctrl+f"),
+				key.WithHelp("ctrl+f", tr("search repositories remotely")),
 			),
 		}
 	}
 
-	l.SetSize(80, 24)
-
-	return repoModel{
-		username: username,
-		repos:    repos,
-		list:     l,
-		spinner:  sp,
+	vim := newVimState()
+	if vim.enabled {
+		applyVimKeys(&l)
 	}
-}
 
-func fetchRepos(username string) ([]*github.Repository, error) {
-	ctx := context.Background()
-	token := os.Getenv("GITHUB_TOKEN")
-
-	var client *github.Client
-	if token != "" {
-		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-		client = github.NewClient(oauth2.NewClient(ctx, ts))
-	} else {
-		client = github.NewClient(nil)
+	m := repoModel{
+		vim:     vim,
+		list:    l,
+		spinner: newSpinner(),
+		partial: cfg.PartialClone,
 	}
-
-	opt := &github.RepositoryListOptions{
-		Type:        "all",
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-
-	var allRepos []*github.Repository
-	for {
-		repos, resp, err := client.Repositories.List(ctx, username, opt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list repos: %w", err)
-		}
-		allRepos = append(allRepos, repos...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opt.Page = resp.NextPage
+	// screens replacing each other don't get a WindowSizeMsg of their own,
+	// so start at the size navModel last passed on
+	m.width, m.height = termSize.Width, termSize.Height
+	m.list.SetSize(80, 24)
+	if m.width > 0 {
+		m.resize()
 	}
-
-	return allRepos, nil
+	return m
 }
 
 func BbltRun() {
 	var model tea.Model
 
-	cmd := exec.Command("git", "config", "user.name")
-	out, err := cmd.CombinedOutput()
+	out, err := runGit("config", "user.name")
 	un := strings.TrimSpace(string(out))
-	if err != nil && un == "" {
-		model = prepUsernameModel("", repoModel{})
+	if lineMode {
+		if s, ok := loadUIState(); ok {
+			un = s.User
+		} else if activeProfile.User != "" {
+			un = activeProfile.User
+		}
+		lineRun(un)
+		return
+	}
+	if !configFound {
+		model = newWizardModel(un)
+	} else if s, ok := loadUIState(); ok {
+		model = initialModel(s.User)
+	} else if activeProfile.User != "" {
+		model = initialModel(activeProfile.User)
+	} else if err != nil && un == "" {
+		model = prepUsernameModel("")
 	} else {
 		model = initialModel(un)
 	}
 
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	runProgram(model)
+}
+
+// BrowseRun opens the TUI for username, or for the configured account
+// when it is empty.
+func BrowseRun(username string) {
+	if username == "" {
+		BbltRun()
+		return
+	}
+	if lineMode {
+		lineRun(username)
+		return
+	}
+	runProgram(initialModel(username))
+}
+
+// SetupRun starts the first-run wizard even when a config file exists.
+func SetupRun() {
+	runProgram(newWizardModel(activeProfile.User))
+}
+
+func runProgram(model tea.Model, opts ...tea.ProgramOption) {
+	if !lineMode {
+		opts = append([]tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}, opts...)
+	}
+	opts = append(opts, pickOptions()...)
+	p := tea.NewProgram(newNavModel(withUIState(model)), opts...)
+	final, err := p.Run()
+	if err != nil {
 		fmt.Printf("Error running program: %v", err)
 		os.Exit(1)
 	}
+	saveUIState(final)
+	printPicked()
 }
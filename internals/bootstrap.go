@@ -0,0 +1,238 @@
+package internals
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bootstrapSteps lists the configured commands for the project types found
+// in dir, ordered by marker file so the run is predictable.
+func bootstrapSteps(dir string) []string {
+	markers := make([]string, 0, len(cfg.Bootstrap))
+	for marker, command := range cfg.Bootstrap {
+		if command != "" {
+			markers = append(markers, marker)
+		}
+	}
+	sort.Strings(markers)
+	var steps []string
+	for _, marker := range markers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			steps = append(steps, cfg.Bootstrap[marker])
+		}
+	}
+	return steps
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+type bootstrapLineMsg string
+
+type bootstrapDoneMsg struct{ err error }
+
+// bootstrapEndedMsg tells the repository list below how the bootstrap of
+// dir went once its pane is closed.
+type bootstrapEndedMsg struct {
+	dir  string
+	done bool
+	err  error
+}
+
+// runBootstrap runs steps one after another in dir, sending their combined
+// output line by line and finally a bootstrapDoneMsg on out.
+func runBootstrap(ctx context.Context, dir string, steps []string, out chan<- tea.Msg) {
+	defer close(out)
+	// send gives up once the pane is closed and nothing reads out anymore.
+	send := func(msg tea.Msg) bool {
+		select {
+		case out <- msg:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	for _, step := range steps {
+		if !send(bootstrapLineMsg("$ " + step)) {
+			return
+		}
+		c := shellCommand(ctx, step)
+		c.Dir = dir
+		r, w := io.Pipe()
+		c.Stdout, c.Stderr = w, w
+		if err := c.Start(); err != nil {
+			send(bootstrapDoneMsg{err: err})
+			return
+		}
+		go func() { w.CloseWithError(c.Wait()) }()
+		sc := bufio.NewScanner(r)
+		for sc.Scan() {
+			if !send(bootstrapLineMsg(sc.Text())) {
+				r.Close()
+				return
+			}
+		}
+		if err := sc.Err(); err != nil {
+			send(bootstrapDoneMsg{err: fmt.Errorf("%s: %w", step, err)})
+			return
+		}
+	}
+	send(bootstrapDoneMsg{})
+}
+
+func waitBootstrap(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// bootstrapModel runs the bootstrap commands for a fresh clone and shows
+// their output as it arrives.
+type bootstrapModel struct {
+	dir    string
+	steps  []string
+	view   viewport.Model
+	lines  []string
+	ctx    context.Context
+	ch     chan tea.Msg
+	cancel context.CancelFunc
+	done   bool
+	err    error
+}
+
+func newBootstrapModel(dir string, steps []string) bootstrapModel {
+	ctx, cancel := context.WithCancel(context.Background())
+	return bootstrapModel{
+		dir:    dir,
+		steps:  steps,
+		view:   viewport.New(0, 0),
+		ctx:    ctx,
+		ch:     make(chan tea.Msg),
+		cancel: cancel,
+	}
+}
+
+func (m bootstrapModel) crumbs() []string { return []string{"bootstrap"} }
+
+func (m bootstrapModel) Init() tea.Cmd {
+	go runBootstrap(m.ctx, m.dir, m.steps, m.ch)
+	return waitBootstrap(m.ch)
+}
+
+// close stops the commands when the pane is left with alt+left.
+func (m bootstrapModel) close() {
+	m.cancel()
+}
+
+func (m bootstrapModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case bootstrapLineMsg:
+		follow := m.view.AtBottom()
+		m.lines = append(m.lines, string(msg))
+		m.view.SetContent(strings.Join(m.lines, "\n"))
+		if follow {
+			m.view.GotoBottom()
+		}
+		return m, waitBootstrap(m.ch)
+	case bootstrapDoneMsg:
+		m.done, m.err = true, msg.err
+		m.cancel()
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.cancel()
+			return m, tea.Quit
+		case "esc", "q":
+			return m, backWith(bootstrapEndedMsg{dir: m.dir, done: m.done, err: m.err})
+		}
+	case tea.WindowSizeMsg:
+		h, v := normalStyle.GetFrameSize()
+		m.view.Width, m.view.Height = msg.Width-h, msg.Height-v-3
+	}
+
+	var cmd tea.Cmd
+	m.view, cmd = m.view.Update(msg)
+	return m, cmd
+}
+
+func (m bootstrapModel) View() string {
+	status := tr("Running...")
+	switch {
+	case m.err != nil:
+		status = errorStyle.Render(m.err.Error())
+	case m.done:
+		status = successStyle.Render(tr("Done"))
+	}
+	return normalStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+		statsTitleStyle.Render(tr("Bootstrapping %s", m.dir)),
+		m.view.View(),
+		status+" · "+tr("↑/↓ scroll · esc: back"),
+	))
+}
+
+// startBootstrap asks whether to run the bootstrap commands for the last
+// clone, if any of the configured project types were found in it.
+func (m repoModel) startBootstrap() (tea.Model, tea.Cmd) {
+	steps := bootstrapSteps(m.lastClone)
+	if len(steps) == 0 {
+		m.cloneError = true
+		m.cloneMsg = tr("No bootstrap command matches %s", m.lastClone)
+		return m, nil
+	}
+	m.bootstrapRun = steps
+	return m, nil
+}
+
+func (m repoModel) updateBootstrapConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		steps := m.bootstrapRun
+		m.bootstrapRun = nil
+		return m, navigate(newBootstrapModel(m.lastClone, steps))
+	case "n", "esc":
+		m.bootstrapRun = nil
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m repoModel) bootstrapConfirmView() string {
+	return errorStyle.Render(tr("Run %s in %s? y: run · n: cancel", strings.Join(m.bootstrapRun, " && "), m.lastClone))
+}
+
+func (m repoModel) bootstrapEnded(msg bootstrapEndedMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		m.cloneError = true
+		m.cloneMsg = tr("Bootstrap of %s failed: %v", msg.dir, msg.err)
+	case msg.done:
+		m.cloneError = false
+		m.cloneMsg = tr("Bootstrapped %s", msg.dir)
+	default:
+		m.cloneError = true
+		m.cloneMsg = tr("Bootstrap of %s was stopped", msg.dir)
+	}
+	return m, nil
+}
@@ -0,0 +1,254 @@
+package internals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v50/github"
+)
+
+const compareColumnWidth = 30
+
+// comparison is what the compare view shows about one repository.
+type comparison struct {
+	name        string // owner/name
+	stars       int
+	forks       int
+	openIssues  int
+	pushed      time.Time
+	release     string
+	releaseDate time.Time
+	languages   []string // top languages with their share, e.g. "Go 81%"
+	err         error
+}
+
+func isNotFound(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
+
+// languageShares turns bytes per language into the top three shares.
+func languageShares(bytes map[string]int) []string {
+	total := 0
+	names := make([]string, 0, len(bytes))
+	for name, n := range bytes {
+		total += n
+		names = append(names, name)
+	}
+	sort.Slice(names, func(a, b int) bool {
+		if bytes[names[a]] != bytes[names[b]] {
+			return bytes[names[a]] > bytes[names[b]]
+		}
+		return names[a] < names[b]
+	})
+	var shares []string
+	for _, name := range names[:min(3, len(names))] {
+		shares = append(shares, fmt.Sprintf("%s %d%%", name, bytes[name]*100/max(total, 1)))
+	}
+	return shares
+}
+
+func loadComparison(it item) comparison {
+	c := comparison{name: it.owner + "/" + it.name}
+	ctx := context.Background()
+	api := newAPI()
+
+	repo, _, err := api.GetRepo(ctx, it.owner, it.name)
+	if err != nil {
+		c.err = err
+		return c
+	}
+	c.stars = repo.GetStargazersCount()
+	c.forks = repo.GetForksCount()
+	c.openIssues = repo.GetOpenIssuesCount()
+	c.pushed = repo.GetPushedAt().Time
+
+	release, _, err := api.LatestRelease(ctx, it.owner, it.name)
+	switch {
+	case err == nil:
+		c.release = release.GetTagName()
+		c.releaseDate = release.GetPublishedAt().Time
+	case !isNotFound(err):
+		c.err = err
+		return c
+	}
+
+	bytes, _, err := api.ListLanguages(ctx, it.owner, it.name)
+	if err != nil {
+		c.err = err
+		return c
+	}
+	c.languages = languageShares(bytes)
+	return c
+}
+
+// markCompare marks the selected repository; marking a second one opens
+// the comparison.
+func (m repoModel) markCompare() (tea.Model, tea.Cmd) {
+	it, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return m, nil
+	}
+	first := m.compareWith
+	switch {
+	case first == nil:
+		m.compareWith = &it
+		m.cloneError = false
+		m.cloneMsg = tr("Marked %s; press m on another repository to compare", it.name)
+		return m, nil
+	case first.owner == it.owner && first.name == it.name:
+		m.compareWith = nil
+		m.cloneMsg = ""
+		return m, nil
+	}
+	m.compareWith = nil
+	m.cloneMsg = ""
+	return m, navigate(newCompareModel(*first, it))
+}
+
+type compareFetchedMsg struct {
+	a, b comparison
+}
+
+func fetchComparison(a, b item) tea.Cmd {
+	return func() tea.Msg {
+		var msg compareFetchedMsg
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); msg.a = loadComparison(a) }()
+		go func() { defer wg.Done(); msg.b = loadComparison(b) }()
+		wg.Wait()
+		return msg
+	}
+}
+
+// compareModel puts two repositories side by side, to help pick between
+// similar libraries.
+type compareModel struct {
+	a, b    item
+	ca, cb  comparison
+	loading bool
+}
+
+func newCompareModel(a, b item) compareModel {
+	return compareModel{a: a, b: b, loading: true}
+}
+
+func (m compareModel) crumbs() []string { return []string{"compare"} }
+
+func (m compareModel) Init() tea.Cmd {
+	if activeProfile.provider() != providerGitHub {
+		return func() tea.Msg {
+			err := fmt.Errorf("comparing is only supported for GitHub profiles")
+			return compareFetchedMsg{a: comparison{err: err}, b: comparison{err: err}}
+		}
+	}
+	return fetchComparison(m.a, m.b)
+}
+
+func (m compareModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case compareFetchedMsg:
+		m.loading = false
+		m.ca, m.cb = msg.a, msg.b
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			return m, goBack
+		}
+	}
+	return m, nil
+}
+
+// compareRow renders one row; better, if set, highlights the winning side.
+func compareRow(label, a, b string, better int) string {
+	cell := lipgloss.NewStyle().Width(compareColumnWidth)
+	left, right := cell, cell
+	if better < 0 {
+		left = left.Inherit(successStyle)
+	} else if better > 0 {
+		right = right.Inherit(successStyle)
+	}
+	return detailLabelStyle.Render(fmt.Sprintf("%-12s", label)) + left.Render(a) + right.Render(b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	}
+	return 0
+}
+
+func releaseText(c comparison) string {
+	if c.release == "" {
+		return "none"
+	}
+	if c.releaseDate.IsZero() {
+		return c.release
+	}
+	return c.release + " (" + relativeTime(c.releaseDate) + ")"
+}
+
+func pushedText(c comparison) string {
+	if c.pushed.IsZero() {
+		return "never"
+	}
+	return relativeTime(c.pushed)
+}
+
+func (m compareModel) View() string {
+	var b strings.Builder
+	b.WriteString(statsTitleStyle.Render(tr("Comparing repositories")) + "\n")
+
+	switch {
+	case m.loading:
+		b.WriteString(tr("Loading...") + "\n")
+	case m.ca.err != nil || m.cb.err != nil:
+		err := m.ca.err
+		if err == nil {
+			err = m.cb.err
+		}
+		b.WriteString(errorStyle.Render(err.Error()) + "\n")
+	default:
+		a, c := m.ca, m.cb
+		rows := []string{
+			compareRow("", detailTitleStyle.Render(a.name), detailTitleStyle.Render(c.name), 0),
+			compareRow("stars", fmt.Sprint(a.stars), fmt.Sprint(c.stars), compareInts(a.stars, c.stars)),
+			compareRow("forks", fmt.Sprint(a.forks), fmt.Sprint(c.forks), compareInts(a.forks, c.forks)),
+			compareRow("open issues", fmt.Sprint(a.openIssues), fmt.Sprint(c.openIssues), 0),
+			compareRow("last push", pushedText(a), pushedText(c), compareInts(int(a.pushed.Unix()), int(c.pushed.Unix()))),
+			compareRow("release", releaseText(a), releaseText(c), 0),
+		}
+		for i := 0; i < max(len(a.languages), len(c.languages)); i++ {
+			label := ""
+			if i == 0 {
+				label = "languages"
+			}
+			var la, lc string
+			if i < len(a.languages) {
+				la = a.languages[i]
+			}
+			if i < len(c.languages) {
+				lc = c.languages[i]
+			}
+			rows = append(rows, compareRow(label, la, lc, 0))
+		}
+		b.WriteString(strings.Join(rows, "\n") + "\n")
+	}
+
+	b.WriteString("\n" + tr("esc: back"))
+	return normalStyle.Render(b.String())
+}
@@ -0,0 +1,181 @@
+package internals
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	"github.com/google/go-github/v50/github"
+)
+
+type config struct {
+	// LargeRepoMB is the size above which cloning asks for confirmation.
+	LargeRepoMB int `json:"large_repo_mb"`
+	// CloneFilter is passed as --filter to git clone when partial clones
+	// are enabled, e.g. "blob:none" or "tree:0".
+	CloneFilter string `json:"clone_filter"`
+	// PartialClone turns partial clones on by default.
+	PartialClone bool `json:"partial_clone"`
+	// Protocol selects the clone URL: "https" (default) or "ssh".
+	Protocol string `json:"protocol"`
+	// Keymap is "default" or "vim", which adds gg and a : command line.
+	Keymap string `json:"keymap"`
+	// RefreshInterval re-fetches the list in the background, e.g. "5m".
+	RefreshInterval string `json:"refresh_interval"`
+	// GitPath is the git executable, for installs outside PATH.
+	GitPath string `json:"git_path"`
+	// SSHCommand is passed to git as GIT_SSH_COMMAND, e.g. for jump hosts.
+	SSHCommand string `json:"ssh_command"`
+	// GitEnv holds extra environment variables for every git command.
+	GitEnv map[string]string `json:"git_env"`
+	// Layout is "flat" (default) or "ghq" for <root>/<host>/<owner>/<repo>.
+	Layout string `json:"layout"`
+	// ForkUpstream is "ask" (default), "always" or "never": whether cloning
+	// a fork adds its parent as the upstream remote.
+	ForkUpstream string `json:"fork_upstream"`
+	// Editor opens clones with o, e.g. "code -n"; defaults to $VISUAL,
+	// $EDITOR, then VS Code.
+	Editor string `json:"editor"`
+	// Bootstrap maps a marker file to the command b runs in a clone that
+	// contains it, e.g. "go.mod": "go mod download". An empty command
+	// turns a default off.
+	Bootstrap map[string]string `json:"bootstrap"`
+	// Notify is "terminal" or "desktop" to be notified when a long clone or
+	// sync finishes; empty turns notifications off.
+	Notify string `json:"notify"`
+	// Locale is the UI language, e.g. "de"; defaults to $LC_ALL,
+	// $LC_MESSAGES, then $LANG.
+	Locale string `json:"locale"`
+	// URLRewrites maps a provider to prefix rewrites applied to clone URLs,
+	// e.g. "github": {"https://github.com/": "git@github.com:"}. The
+	// longest matching prefix wins.
+	URLRewrites map[string]map[string]string `json:"url_rewrites"`
+	// Scaffold is what x substitutes in a clone of a template repository.
+	Scaffold scaffoldConfig `json:"scaffold"`
+
+	Profiles       map[string]profile `json:"profiles"`
+	DefaultProfile string             `json:"default_profile"`
+}
+
+var (
+	cfg         = defaultConfig()
+	configFound bool
+)
+
+func defaultConfig() config {
+	return config{
+		LargeRepoMB: 500,
+		CloneFilter: "blob:none",
+		Bootstrap: map[string]string{
+			"go.mod":       "go mod download",
+			"package.json": "npm install",
+			"Cargo.toml":   "cargo fetch",
+		},
+	}
+}
+
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gitls", "config.json"), nil
+}
+
+// LoadConfig reads the user's config file, keeping defaults for anything
+// it doesn't set. A missing file is not an error.
+func LoadConfig() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	c := defaultConfig()
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	cfg = c
+	configFound = true
+	return nil
+}
+
+func saveConfig() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+	configFound = true
+	return nil
+}
+
+func cloneURL(repo *github.Repository) string {
+	if cfg.Protocol == "ssh" && repo.GetSSHURL() != "" {
+		return repo.GetSSHURL()
+	}
+	return repo.GetCloneURL()
+}
+
+// urlRewrites are the url_rewrites of the active profile's provider.
+func urlRewrites() []gitls.Rewrite {
+	var rules []gitls.Rewrite
+	for from, to := range cfg.URLRewrites[activeProfile.provider()] {
+		rules = append(rules, gitls.Rewrite{From: from, To: to})
+	}
+	return rules
+}
+
+// rewriteURL is the URL git actually clones url from.
+func rewriteURL(url string) string {
+	return gitls.RewriteURL(url, urlRewrites())
+}
+
+// DefaultCloneFilter is the --filter default for batch commands: the
+// configured filter when partial clones are on by default, empty otherwise.
+func DefaultCloneFilter() string {
+	if cfg.PartialClone {
+		return cfg.CloneFilter
+	}
+	return ""
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// DefaultCloneDir is where batch commands clone to unless told otherwise.
+func DefaultCloneDir() string {
+	if activeProfile.CloneDir != "" {
+		return expandHome(activeProfile.CloneDir)
+	}
+	if cfg.Layout == string(gitls.LayoutGhq) {
+		return ghqRoot()
+	}
+	return "."
+}
@@ -0,0 +1,105 @@
+package internals
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// destConflict is a clone waiting on what to do about its destination.
+type destConflict struct {
+	it     item
+	opts   gitls.CloneOptions
+	dest   string
+	reason string
+}
+
+// remoteKey reduces a clone URL to host/owner/name so HTTPS and SSH
+// remotes of the same repository compare equal.
+func remoteKey(u string) string {
+	u = strings.ToLower(strings.TrimSpace(u))
+	if _, rest, ok := strings.Cut(u, "://"); ok {
+		u = rest
+	} else if host, path, ok := strings.Cut(u, ":"); ok {
+		u = host + "/" + path // scp-like git@host:owner/name
+	}
+	if _, rest, ok := strings.Cut(u, "@"); ok {
+		u = rest
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(u, "/"), ".git")
+}
+
+// checkDest reports why dest can't take a clone of it, or that it already
+// holds one. A missing or empty directory is fine.
+func checkDest(it item, dest string) (reason string, cloned bool) {
+	info, err := os.Stat(dest)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false
+	}
+	if err != nil {
+		return err.Error(), false
+	}
+	if !info.IsDir() {
+		return tr("is a file"), false
+	}
+	entries, err := os.ReadDir(dest)
+	if err != nil {
+		return err.Error(), false
+	}
+	if len(entries) == 0 {
+		return "", false
+	}
+	if _, err := os.Stat(filepath.Join(dest, ".git")); err != nil {
+		return tr("exists and isn't a git repository"), false
+	}
+	out, err := runGit("-C", dest, "remote", "get-url", "origin")
+	if err != nil {
+		return tr("is a git repository without an origin remote"), false
+	}
+	remote := strings.TrimSpace(string(out))
+	if key := remoteKey(remote); key != remoteKey(it.url) && key != remoteKey(rewriteURL(it.url)) {
+		return tr("is a clone of %s", remote), false
+	}
+	return "", true
+}
+
+// freeDest is the first of dest-2, dest-3, ... that doesn't exist.
+func freeDest(dest string) string {
+	for n := 2; ; n++ {
+		alt := fmt.Sprintf("%s-%d", dest, n)
+		if _, err := os.Lstat(alt); errors.Is(err, fs.ErrNotExist) {
+			return alt
+		}
+	}
+}
+
+func (m repoModel) updateConflict(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := m.conflict
+	switch msg.String() {
+	case "r":
+		m.conflict = nil
+		return m.cloneInto(c.it, freeDest(c.dest), false, c.opts)
+	case "o":
+		m.conflict = nil
+		return m.cloneInto(c.it, c.dest, true, c.opts)
+	case "n", "esc":
+		m.conflict = nil
+		m.cloneError = false
+		m.cloneMsg = tr("Clone cancelled")
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m repoModel) conflictView() string {
+	c := m.conflict
+	return errorStyle.Render(tr("%s/ %s.", c.dest, c.reason)) + "\n" +
+		tr("r: clone into %s/ · o: overwrite (moves it to the trash) · n: cancel", freeDest(c.dest))
+}
@@ -0,0 +1,118 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v50/github"
+)
+
+type contributor struct {
+	login   string
+	commits int
+}
+
+func (c contributor) Title() string       { return c.login }
+func (c contributor) Description() string { return plural(c.commits, "commit") }
+func (c contributor) FilterValue() string { return c.login }
+
+type contributorsFetchedMsg struct {
+	contributors []list.Item
+	err          error
+}
+
+// fetchContributors lists the top contributors of a repository, the API
+// returns them by commit count.
+func fetchContributors(owner, repo string) tea.Cmd {
+	return func() tea.Msg {
+		if activeProfile.provider() != providerGitHub {
+			return contributorsFetchedMsg{err: fmt.Errorf("contributors are only supported for GitHub profiles")}
+		}
+		opts := &github.ListContributorsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		found, _, err := newAPI().ListContributors(context.Background(), owner, repo, opts)
+		if err != nil {
+			return contributorsFetchedMsg{err: fmt.Errorf("failed to fetch contributors: %w", err)}
+		}
+		items := make([]list.Item, len(found))
+		for i, c := range found {
+			items[i] = contributor{login: c.GetLogin(), commits: c.GetContributions()}
+		}
+		return contributorsFetchedMsg{contributors: items}
+	}
+}
+
+// contributorsModel lists a repository's contributors; enter opens the
+// selected contributor's own repositories.
+type contributorsModel struct {
+	it      item
+	list    list.Model
+	loading bool
+	err     error
+}
+
+func newContributorsModel(it item) contributorsModel {
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	localizeList(&l)
+	l.Title = tr("%s/%s contributors", it.owner, it.name)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", tr("list their repositories"))),
+		}
+	}
+	return contributorsModel{it: it, list: l, loading: true}
+}
+
+func (m contributorsModel) crumbs() []string { return []string{"contributors"} }
+
+func (m contributorsModel) Init() tea.Cmd {
+	return fetchContributors(m.it.owner, m.it.name)
+}
+
+func (m contributorsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case contributorsFetchedMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, m.list.SetItems(msg.contributors)
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			if m.list.FilterState() == list.Unfiltered {
+				return m, goBack
+			}
+		case "enter":
+			c, ok := m.list.SelectedItem().(contributor)
+			if !ok {
+				return m, nil
+			}
+			return m, navigate(initialModel(c.login))
+		}
+	case tea.WindowSizeMsg:
+		h, v := normalStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v-1)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m contributorsModel) View() string {
+	body := m.list.View()
+	if m.loading {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("Loading contributors..."))
+	}
+	if m.err != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, errorStyle.Render(m.err.Error()))
+	}
+	return normalStyle.Render(body)
+}
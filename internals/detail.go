@@ -0,0 +1,151 @@
+package internals
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	detailStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(0, 1)
+	detailTitleStyle = lipgloss.NewStyle().Bold(true)
+	detailLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+// paneLayout is where the detail pane goes, which depends on the terminal
+// size.
+type paneLayout int
+
+const (
+	layoutList    paneLayout = iota // detail pane hidden
+	layoutSplit                     // detail pane right of the list
+	layoutStacked                   // detail pane below the list
+)
+
+const (
+	// minDetailWidth is the terminal width below which the detail pane
+	// moves under the list.
+	minDetailWidth = 100
+	// minStackedHeight is the height below which a narrow terminal only
+	// shows the list.
+	minStackedHeight = 30
+
+	defaultSplit = 60 // percent of the width (or height) given to the list
+	minSplit     = 30
+	maxSplit     = 80
+	splitStep    = 5
+)
+
+func (m repoModel) layout() paneLayout {
+	switch {
+	case m.hidePreview || m.width == 0:
+		return layoutList
+	case m.width >= minDetailWidth:
+		return layoutSplit
+	case m.height >= minStackedHeight:
+		return layoutStacked
+	}
+	return layoutList
+}
+
+func (m repoModel) splitRatio() int {
+	if m.split == 0 {
+		return defaultSplit
+	}
+	return m.split
+}
+
+// resizeSplit moves the divider by delta percent.
+func (m *repoModel) resizeSplit(delta int) {
+	m.split = min(max(m.splitRatio()+delta, minSplit), maxSplit)
+	m.resize()
+}
+
+// inDetail reports whether a mouse event is over the detail pane.
+func (m repoModel) inDetail(msg tea.MouseMsg) bool {
+	switch m.layout() {
+	case layoutSplit:
+		return msg.X > m.list.Width()+normalStyle.GetMarginLeft()
+	case layoutStacked:
+		return msg.Y > m.list.Height()+normalStyle.GetMarginTop()
+	}
+	return false
+}
+
+func detailRow(label, value string) string {
+	return detailLabelStyle.Render(fmt.Sprintf("%-9s", label)) + value
+}
+
+func readmePreview(text string, maxLines int) []string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) > maxLines {
+		lines = append(lines[:maxLines], "…")
+	}
+	return lines
+}
+
+func (m repoModel) detailView(width, height int) string {
+	it, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return ""
+	}
+
+	rows := []string{detailTitleStyle.Render(it.Title())}
+	if it.description != "" {
+		rows = append(rows, "", it.description)
+	}
+	if it.note != "" {
+		rows = append(rows, "", detailRow(tr("note"), it.note))
+	}
+	rows = append(rows,
+		"",
+		detailRow(tr("size"), humanSize(it.size)),
+		detailRow(tr("stars"), fmt.Sprint(it.stars)),
+	)
+	if it.language != "" {
+		rows = append(rows, detailRow(tr("language"), it.language))
+	}
+	if !it.updated.IsZero() {
+		rows = append(rows, detailRow(tr("updated"), relativeTime(it.updated)))
+	}
+	license := it.license
+	if license == "" {
+		license = tr("none")
+	}
+	rows = append(rows, detailRow(tr("license"), license))
+	if topics := itemTopics(it); len(topics) > 0 {
+		rows = append(rows, detailRow(tr("topics"), topicChips(topics)))
+	}
+	rows = append(rows, detailRow(tr("clone"), it.url))
+	if ci, ok := ciStatusFor(it); ok {
+		rows = append(rows, detailRow(tr("ci"), ci.String()))
+	}
+
+	if e, ok := enrichmentFor(it); ok {
+		if e.release != "" {
+			rows = append(rows, detailRow(tr("release"), e.release))
+		}
+		if len(e.languages) > 0 {
+			rows = append(rows, detailRow(tr("langs"), strings.Join(e.languages, ", ")))
+		}
+	}
+	if meta, ok := metaFor(it.fullName); ok {
+		if meta.Readme != "" {
+			rows = append(rows, "", detailTitleStyle.Render("README"))
+			rows = append(rows, readmePreview(meta.Readme, 15)...)
+		}
+	}
+	rows = rows[min(m.detailOffset, len(rows)-1):]
+
+	frameW, frameH := detailStyle.GetFrameSize()
+	return detailStyle.
+		Width(width - frameW).
+		Height(height - frameH).
+		MaxHeight(height).
+		Render(strings.Join(rows, "\n"))
+}
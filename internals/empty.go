@@ -0,0 +1,153 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v50/github"
+)
+
+// maxListPages caps how many pages of stars or gists are fetched.
+const maxListPages = 10
+
+// otherListMsg carries a starred or gists listing opened from an account.
+type otherListMsg struct {
+	model repoModel
+	err   error
+}
+
+// emptyAccount reports whether m lists an account that has no
+// repositories at all, as opposed to one filtered down to nothing.
+func (m repoModel) emptyAccount() bool {
+	return m.username != "" && m.crumb == "" && len(m.repos) == 0 && len(m.allItems()) == 0
+}
+
+func (m repoModel) emptyView() string {
+	var b strings.Builder
+	b.WriteString(m.list.Styles.Title.Render(m.list.Title) + "\n\n")
+	if m.info.login != "" && strings.EqualFold(m.info.login, m.username) {
+		b.WriteString(tr("You don't have any repositories yet.") + "\n\n")
+	} else {
+		b.WriteString(tr("%s has no public repositories.", m.username) + "\n\n")
+	}
+	actions := []string{tr("c: change user")}
+	if activeProfile.provider() == providerGitHub {
+		actions = append(actions, tr("B: starred"), tr("X: gists"), tr("e: everything"))
+	}
+	b.WriteString(badgeStyle.Render(strings.Join(actions, " • ")))
+	return lipgloss.NewStyle().Width(m.list.Width()).Height(m.list.Height()).Render(b.String())
+}
+
+// starredItems lists the repositories user has starred.
+func starredItems(user string) ([]list.Item, error) {
+	if activeProfile.provider() != providerGitHub {
+		return nil, fmt.Errorf("starred repositories are only supported for GitHub profiles")
+	}
+	ctx := context.Background()
+	opts := &github.ActivityListStarredOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var items []list.Item
+	for page := 0; page < maxListPages; page++ {
+		starred, resp, err := newAPI().ListStarred(ctx, user, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list starred repositories: %w", err)
+		}
+		for _, s := range starred {
+			it := newItem(s.GetRepository())
+			it.showOwner = true
+			items = append(items, it)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return items, nil
+}
+
+// gistItems lists user's public gists. Gists are git repositories, so they
+// clone like any other item, into a directory named after the gist id.
+func gistItems(user string) ([]list.Item, error) {
+	if activeProfile.provider() != providerGitHub {
+		return nil, fmt.Errorf("gists are only supported for GitHub profiles")
+	}
+	ctx := context.Background()
+	opts := &github.GistListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var items []list.Item
+	for page := 0; page < maxListPages; page++ {
+		gists, resp, err := newAPI().ListGists(ctx, user, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list gists: %w", err)
+		}
+		for _, g := range gists {
+			items = append(items, gistItem(user, g))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return items, nil
+}
+
+func starredList(user string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := starredItems(user)
+		if err != nil {
+			return otherListMsg{err: err}
+		}
+		m := newRepoModel(tr("%s's Starred Repositories", user), items)
+		m.crumb = user + " starred"
+		return otherListMsg{model: m}
+	}
+}
+
+func gistList(user string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := gistItems(user)
+		if err != nil {
+			return otherListMsg{err: err}
+		}
+		sortItems(items, sortByUpdated)
+		m := newRepoModel(tr("%s's Gists", user), items)
+		m.crumb = user + " gists"
+		m.sort = sortByUpdated
+		return otherListMsg{model: m}
+	}
+}
+
+func gistItem(user string, g *github.Gist) item {
+	var files []string
+	for name := range g.Files {
+		files = append(files, string(name))
+	}
+	sort.Strings(files)
+	desc := strings.Join(files, ", ")
+	if d := g.GetDescription(); d != "" {
+		desc = d + " (" + desc + ")"
+	}
+	return item{
+		name:        g.GetID(),
+		url:         g.GetGitPullURL(),
+		private:     !g.GetPublic(),
+		updated:     g.GetUpdatedAt().Time,
+		description: desc,
+		owner:       user,
+		fullName:    user + "/" + g.GetID(),
+		gist:        true,
+	}
+}
+
+func (m repoModel) openOtherList(msg otherListMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.cloneError = true
+		m.cloneMsg = msg.err.Error()
+		return m, nil
+	}
+	m.cloneMsg = ""
+	return m, navigate(msg.model)
+}
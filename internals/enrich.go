@@ -0,0 +1,129 @@
+package internals
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// enrichWorkers bounds how many repositories are enriched at once.
+const enrichWorkers = 4
+
+// enrichRetryAfter is how long a failed enrichment is kept before the row
+// is fetched again.
+const enrichRetryAfter = time.Minute
+
+// enrichment is metadata the listing leaves out, fetched lazily for the
+// rows on screen.
+type enrichment struct {
+	languages []string // largest first, with shares when enriched
+	topics    []string
+	release   string // latest release tag
+	pending   bool
+	err       error
+	retryAt   time.Time // when a failed fetch may be tried again
+}
+
+// enrichCache holds enrichments by owner/name for the session, failed ones
+// until enrichRetryAfter has passed.
+var enrichCache sync.Map
+
+var enrichSlots = make(chan struct{}, enrichWorkers)
+
+func enrichmentFor(it item) (enrichment, bool) {
+	v, ok := enrichCache.Load(it.owner + "/" + it.name)
+	if !ok {
+		return enrichment{}, false
+	}
+	e := v.(enrichment)
+	return e, !e.pending
+}
+
+// enrichedMsg only triggers a redraw; the data is already in enrichCache.
+type enrichedMsg struct{}
+
+// itemTopics returns the listing's topics, or the enriched ones when the
+// listing had none to give.
+func itemTopics(it item) []string {
+	if it.topics != nil {
+		return it.topics
+	}
+	e, _ := enrichmentFor(it)
+	return e.topics
+}
+
+// wantEnrich fetches the missing metadata for the rows on the current page.
+func (m repoModel) wantEnrich() tea.Cmd {
+	if _, limited := rateLimited(); limited || activeProfile.provider() != providerGitHub {
+		return nil
+	}
+	visible := m.list.VisibleItems()
+	start, end := m.list.Paginator.GetSliceBounds(len(visible))
+	var cmds []tea.Cmd
+	for _, li := range visible[start:end] {
+		it, ok := li.(item)
+		if !ok || it.gist {
+			continue
+		}
+		key := it.owner + "/" + it.name
+		if v, loaded := enrichCache.LoadOrStore(key, enrichment{pending: true}); loaded {
+			if e := v.(enrichment); e.err == nil || time.Now().Before(e.retryAt) {
+				continue
+			}
+			enrichCache.Store(key, enrichment{pending: true})
+		}
+		cmds = append(cmds, func() tea.Msg {
+			enrichSlots <- struct{}{}
+			defer func() { <-enrichSlots }()
+			e := loadEnrichment(it)
+			if e.err != nil {
+				e.retryAt = time.Now().Add(enrichRetryAfter)
+			}
+			enrichCache.Store(key, e)
+			return enrichedMsg{}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadEnrichment skips what a GraphQL listing already found.
+func loadEnrichment(it item) enrichment {
+	var e enrichment
+	ctx := context.Background()
+	api := newAPI()
+	meta, hasMeta := metaFor(it.fullName)
+	e.languages = meta.Languages
+
+	release, _, err := api.LatestRelease(ctx, it.owner, it.name)
+	switch {
+	case err == nil:
+		e.release = release.GetTagName()
+	case !isNotFound(err):
+		logger.Debug("latest release", "repo", it.owner+"/"+it.name, "err", err)
+		e.err = err
+		return e
+	}
+	if !hasMeta {
+		bytes, _, err := api.ListLanguages(ctx, it.owner, it.name)
+		if err != nil {
+			logger.Debug("languages", "repo", it.owner+"/"+it.name, "err", err)
+			e.err = err
+			return e
+		}
+		e.languages = languageShares(bytes)
+	}
+	// REST listings always carry topics, even if empty; nil means the
+	// listing couldn't provide them.
+	if it.topics == nil && !hasMeta {
+		repo, _, err := api.GetRepo(ctx, it.owner, it.name)
+		if err != nil {
+			logger.Debug("topics", "repo", it.owner+"/"+it.name, "err", err)
+			e.err = err
+			return e
+		}
+		e.topics = repo.Topics
+	}
+	return e
+}
@@ -0,0 +1,134 @@
+package internals
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v50/github"
+)
+
+type fetchErrKind int
+
+const (
+	fetchErrUnknown fetchErrKind = iota
+	fetchErrNotFound
+	fetchErrRateLimit
+	fetchErrNetwork
+)
+
+func (k fetchErrKind) String() string {
+	switch k {
+	case fetchErrNotFound:
+		return "user not found (404)"
+	case fetchErrRateLimit:
+		return "rate limited or forbidden (403)"
+	case fetchErrNetwork:
+		return "network error"
+	default:
+		return "unexpected error"
+	}
+}
+
+func classifyFetchErr(err error) fetchErrKind {
+	var rle *github.RateLimitError
+	var arle *github.AbuseRateLimitError
+	var limited *errRateLimited
+	if errors.As(err, &rle) || errors.As(err, &arle) || errors.As(err, &limited) {
+		return fetchErrRateLimit
+	}
+
+	var er *github.ErrorResponse
+	if errors.As(err, &er) && er.Response != nil {
+		switch er.Response.StatusCode {
+		case http.StatusNotFound:
+			return fetchErrNotFound
+		case http.StatusForbidden:
+			return fetchErrRateLimit
+		}
+	}
+
+	var ne net.Error
+	if errors.As(err, &ne) {
+		return fetchErrNetwork
+	}
+
+	return fetchErrUnknown
+}
+
+type errorModel struct {
+	username string
+	err      error
+	kind     fetchErrKind
+	reset    time.Time // when a used-up rate limit resets, to retry then
+}
+
+func newErrorModel(username string, err error) errorModel {
+	m := errorModel{
+		username: username,
+		err:      err,
+		kind:     classifyFetchErr(err),
+	}
+	if reset, limited := rateLimited(); limited && m.kind == fetchErrRateLimit {
+		m.reset = reset
+	}
+	return m
+}
+
+func (m errorModel) crumbs() []string { return []string{m.username} }
+
+func (m errorModel) Init() tea.Cmd {
+	if m.reset.IsZero() {
+		return nil
+	}
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return rateTickMsg{} })
+}
+
+// retry lists the account again, keeping the countdown going if the rate
+// limit is still used up.
+func (m errorModel) retry() (tea.Model, tea.Cmd) {
+	model := initialModel(m.username)
+	return model, model.Init()
+}
+
+func (m errorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(rateTickMsg); ok && !m.reset.IsZero() {
+		if time.Now().Before(m.reset) {
+			return m, m.Init()
+		}
+		return m.retry()
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "r":
+			return m.retry()
+		case "c":
+			return m, navigate(prepUsernameModel(m.username))
+		case "esc":
+			return m, goBack
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m errorModel) View() string {
+	if !m.reset.IsZero() {
+		return normalStyle.Render(fmt.Sprintf(
+			"%s\n\n%s\n\n%s",
+			errorStyle.Render(tr("The API rate limit is used up and %s's repositories aren't cached yet.", m.username)),
+			tr("It resets in %s; gitls lists them then. A token raises the limit from 60 to 5000 requests an hour (gitls login).", countdown(m.reset)),
+			tr("c: change username · esc: back · q: quit"),
+		))
+	}
+	return normalStyle.Render(fmt.Sprintf(
+		"%s\n\n%v\n\n%s",
+		errorStyle.Render(fmt.Sprintf("Error fetching repos for %s: %s", m.username, m.kind)),
+		m.err,
+		tr("r: retry · c: change username · esc: back · q: quit"),
+	))
+}
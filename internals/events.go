@@ -0,0 +1,214 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v50/github"
+)
+
+type feedEntry struct {
+	summary string
+	repo    string // owner/name
+	time    time.Time
+}
+
+func (e feedEntry) Title() string       { return e.summary }
+func (e feedEntry) Description() string { return e.repo + " · " + relativeTime(e.time) }
+func (e feedEntry) FilterValue() string { return e.repo + " " + e.summary }
+
+func summarizeEvent(e *github.Event) string {
+	payload, err := e.ParsePayload()
+	if err != nil {
+		return strings.TrimSuffix(e.GetType(), "Event")
+	}
+	switch p := payload.(type) {
+	case *github.PushEvent:
+		return fmt.Sprintf("pushed %s to %s", plural(p.GetSize(), "commit"), strings.TrimPrefix(p.GetRef(), "refs/heads/"))
+	case *github.CreateEvent:
+		if p.GetRefType() == "repository" {
+			return "created repository"
+		}
+		return fmt.Sprintf("created %s %s", p.GetRefType(), p.GetRef())
+	case *github.DeleteEvent:
+		return fmt.Sprintf("deleted %s %s", p.GetRefType(), p.GetRef())
+	case *github.ReleaseEvent:
+		return fmt.Sprintf("%s release %s", p.GetAction(), p.GetRelease().GetTagName())
+	case *github.WatchEvent:
+		return "starred"
+	case *github.ForkEvent:
+		return "forked to " + p.GetForkee().GetFullName()
+	case *github.IssuesEvent:
+		return fmt.Sprintf("%s issue #%d", p.GetAction(), p.GetIssue().GetNumber())
+	case *github.PullRequestEvent:
+		return fmt.Sprintf("%s pull request #%d", p.GetAction(), p.GetNumber())
+	case *github.PublicEvent:
+		return "made public"
+	default:
+		return strings.TrimSuffix(e.GetType(), "Event")
+	}
+}
+
+type eventsFetchedMsg struct {
+	entries []list.Item
+	err     error
+}
+
+func fetchEvents(username string) tea.Cmd {
+	return func() tea.Msg {
+		if activeProfile.provider() != providerGitHub {
+			return eventsFetchedMsg{err: fmt.Errorf("the activity feed is only supported for GitHub profiles")}
+		}
+		ctx := context.Background()
+		events, _, err := newAPI().UserEvents(ctx, username, &github.ListOptions{PerPage: 100})
+		if err != nil {
+			return eventsFetchedMsg{err: fmt.Errorf("failed to fetch events: %w", err)}
+		}
+		entries := make([]list.Item, len(events))
+		for i, e := range events {
+			entries[i] = feedEntry{
+				summary: summarizeEvent(e),
+				repo:    e.GetRepo().GetName(),
+				time:    e.GetCreatedAt().Time,
+			}
+		}
+		return eventsFetchedMsg{entries: entries}
+	}
+}
+
+// eventsModel shows a user's recent public activity. Enter jumps to the
+// entry's repository, in the current list when it belongs to the same
+// account and in that owner's list otherwise.
+type eventsModel struct {
+	username string
+	list     list.Model
+	loading  bool
+	opening  string // repository being looked up after enter
+	err      error
+}
+
+func newEventsModel(username string) eventsModel {
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	localizeList(&l)
+	l.Title = tr("%s's activity", username)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", tr("jump to repository"))),
+		}
+	}
+	return eventsModel{username: username, list: l, loading: true}
+}
+
+func (m eventsModel) crumbs() []string { return []string{"activity"} }
+
+func (m eventsModel) Init() tea.Cmd {
+	return fetchEvents(m.username)
+}
+
+func (m eventsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case eventsFetchedMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, m.list.SetItems(msg.entries)
+	case pageLoadedMsg:
+		m.opening = ""
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m, navigate(msg.model)
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			if m.list.FilterState() == list.Unfiltered {
+				return m, goBack
+			}
+		case "enter":
+			e, ok := m.list.SelectedItem().(feedEntry)
+			if !ok || m.opening != "" {
+				return m, nil
+			}
+			return m.jumpTo(e.repo)
+		}
+	case tea.WindowSizeMsg:
+		h, v := normalStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v-1)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m eventsModel) jumpTo(fullName string) (tea.Model, tea.Cmd) {
+	cmd, back := jumpToRepo(m.username, fullName)
+	if !back {
+		m.opening, m.err = fullName, nil
+	}
+	return m, cmd
+}
+
+// selectRepoMsg tells the repository list below to select name.
+type selectRepoMsg struct{ name string }
+
+// jumpToRepo goes back and selects fullName in the list below when it
+// belongs to listUser, the account that list shows, reporting back.
+// Otherwise it fetches the owner's list and sends it in a pageLoadedMsg.
+func jumpToRepo(listUser, fullName string) (cmd tea.Cmd, back bool) {
+	owner, name, _ := strings.Cut(fullName, "/")
+	if strings.EqualFold(owner, listUser) {
+		return backWith(selectRepoMsg{name: name}), true
+	}
+
+	return func() tea.Msg {
+		next := initialModel(owner)
+		rm, ok := next.(repoModel)
+		if !ok {
+			return pageLoadedMsg{model: next}
+		}
+		if !rm.selectByName(name) {
+			return pageLoadedMsg{err: fmt.Errorf("%s is not visible in %s's repositories", fullName, owner)}
+		}
+		return pageLoadedMsg{model: rm}
+	}, false
+}
+
+// selectByName moves the cursor to the repository called name, clearing any
+// filter that would hide it.
+func (m *repoModel) selectByName(name string) bool {
+	for i, li := range m.list.Items() {
+		if it, ok := li.(item); ok && strings.EqualFold(it.name, name) {
+			m.list.ResetFilter()
+			m.list.Select(i)
+			m.detailOffset = 0
+			return true
+		}
+	}
+	return false
+}
+
+func (m eventsModel) View() string {
+	body := m.list.View()
+	if m.loading {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("Loading activity..."))
+	}
+	if m.opening != "" {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("Loading %s...", m.opening))
+	}
+	if m.err != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, errorStyle.Render(m.err.Error()))
+	}
+	return normalStyle.Render(body)
+}
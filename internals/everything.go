@@ -0,0 +1,83 @@
+package internals
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Sources of the items in the everything view, shown as badges.
+const (
+	sourceOwned   = "owned"
+	sourceStarred = "starred"
+	sourceGist    = "gist"
+)
+
+// everythingList merges user's repositories, stars and gists into one list
+// so a single filter searches all of them. A source that fails to load is
+// reported in the list rather than hiding the others.
+func everythingList(user string) tea.Cmd {
+	return func() tea.Msg {
+		sources := []struct {
+			name  string
+			fetch func(string) ([]list.Item, error)
+		}{
+			{sourceOwned, ownedItems},
+			{sourceStarred, starredItems},
+			{sourceGist, gistItems},
+		}
+		found := make([][]list.Item, len(sources))
+		errs := make([]error, len(sources))
+		var wg sync.WaitGroup
+		for i, src := range sources {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				found[i], errs[i] = src.fetch(user)
+			}()
+		}
+		wg.Wait()
+
+		var items []list.Item
+		var failed []string
+		for i, src := range sources {
+			if errs[i] != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", src.name, errs[i]))
+				continue
+			}
+			for _, li := range found[i] {
+				it := li.(item)
+				it.source = src.name
+				it.showOwner = true
+				items = append(items, it)
+			}
+		}
+		if len(failed) == len(sources) {
+			return otherListMsg{err: fmt.Errorf("failed to list anything for %s: %s", user, strings.Join(failed, "; "))}
+		}
+
+		sortItems(items, sortByName)
+		m := newRepoModel(tr("Everything of %s", user), items)
+		m.crumb = user + " everything"
+		if len(failed) > 0 {
+			m.cloneError = true
+			m.cloneMsg = tr("Partly loaded. %s", strings.Join(failed, "; "))
+		}
+		return otherListMsg{model: m}
+	}
+}
+
+func ownedItems(user string) ([]list.Item, error) {
+	repos, _, err := fetchRepos(user)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]list.Item, len(repos))
+	for i, repo := range repos {
+		items[i] = newItem(repo)
+	}
+	return items, nil
+}
@@ -0,0 +1,221 @@
+package internals
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ExportOptions struct {
+	Format  string // table, json, csv or urls; empty picks one from Out's extension
+	Out     string // file to write, empty or "-" for stdout
+	Include []string
+	Exclude []string
+	Sort    string // name, stars or updated
+}
+
+type exportRecord struct {
+	Name          string    `json:"name"`
+	Owner         string    `json:"owner"`
+	CloneURL      string    `json:"clone_url"`
+	Description   string    `json:"description,omitempty"`
+	Language      string    `json:"language,omitempty"`
+	Stars         int       `json:"stars"`
+	SizeKB        int       `json:"size_kb"`
+	Private       bool      `json:"private"`
+	Fork          bool      `json:"fork"`
+	Archived      bool      `json:"archived"`
+	DefaultBranch string    `json:"default_branch,omitempty"`
+	Updated       time.Time `json:"updated"`
+}
+
+func newExportRecord(it item) exportRecord {
+	return exportRecord{
+		Name:          it.name,
+		Owner:         it.owner,
+		CloneURL:      it.url,
+		Description:   it.description,
+		Language:      it.language,
+		Stars:         it.stars,
+		SizeKB:        it.size,
+		Private:       it.private,
+		Fork:          it.fork,
+		Archived:      it.archived,
+		DefaultBranch: it.defaultBranch,
+		Updated:       it.updated,
+	}
+}
+
+// exportFormat picks the format from the file extension: .json, .csv, or a
+// plain list of clone URLs for anything else.
+func exportFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".csv":
+		return "csv"
+	default:
+		return "urls"
+	}
+}
+
+func writeExport(w io.Writer, format string, items []item) error {
+	switch format {
+	case "json":
+		records := make([]exportRecord, len(items))
+		for i, it := range items {
+			records[i] = newExportRecord(it)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"name", "owner", "clone_url", "description", "language", "stars", "size_kb", "private", "fork", "archived", "default_branch", "updated"})
+		for _, it := range items {
+			r := newExportRecord(it)
+			cw.Write([]string{
+				r.Name, r.Owner, r.CloneURL, r.Description, r.Language,
+				strconv.Itoa(r.Stars), strconv.Itoa(r.SizeKB),
+				strconv.FormatBool(r.Private), strconv.FormatBool(r.Fork), strconv.FormatBool(r.Archived),
+				r.DefaultBranch, r.Updated.Format(time.RFC3339),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, it := range items {
+			updated := ""
+			if !it.updated.IsZero() {
+				updated = relativeTime(it.updated)
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", it.name, it.stars, it.language, updated, it.description)
+		}
+		return tw.Flush()
+	case "urls":
+		for _, it := range items {
+			if _, err := fmt.Fprintln(w, it.url); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q (want table, json, csv or urls)", format)
+	}
+}
+
+func exportToFile(path, format string, items []item) error {
+	f, err := os.Create(expandHome(path))
+	if err != nil {
+		return err
+	}
+	if err := writeExport(f, format, items); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func parseSortMode(s string) (sortMode, error) {
+	for mode := sortByName; mode <= sortByUpdated; mode++ {
+		if mode.String() == s {
+			return mode, nil
+		}
+	}
+	return sortByName, fmt.Errorf("unknown sort %q (want name, stars or updated)", s)
+}
+
+// Export writes account's repositories to opts.Out without starting the TUI.
+func Export(account string, opts ExportOptions) error {
+	mode, err := parseSortMode(opts.Sort)
+	if err != nil {
+		return err
+	}
+	format := opts.Format
+	if format == "" {
+		format = exportFormat(opts.Out)
+	}
+
+	repos, _, err := fetchRepos(account)
+	if err != nil {
+		return err
+	}
+	repos = selectRepos(repos, BatchOptions{Include: opts.Include, Exclude: opts.Exclude})
+
+	listItems := make([]list.Item, len(repos))
+	for i, repo := range repos {
+		listItems[i] = newItem(repo)
+	}
+	sortItems(listItems, mode)
+	items := make([]item, len(listItems))
+	for i, li := range listItems {
+		items[i] = li.(item)
+	}
+
+	if opts.Out == "" || opts.Out == "-" {
+		return writeExport(os.Stdout, format, items)
+	}
+	return exportToFile(opts.Out, format, items)
+}
+
+func newExportInput(username string) textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = tr("export to: ")
+	ti.Placeholder = tr("repos.json, repos.csv or repos.txt")
+	ti.CharLimit = 256
+	ti.SetValue(username + "-repos.json")
+	ti.Focus()
+	return ti
+}
+
+func (m repoModel) startExport() (tea.Model, tea.Cmd) {
+	m.exportInput = newExportInput(m.username)
+	m.exporting = true
+	return m, textinput.Blink
+}
+
+// updateExport writes what's currently visible, so the active filter and
+// sort carry over to the file.
+func (m repoModel) updateExport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.exporting = false
+		return m, nil
+	case tea.KeyEnter:
+		path := strings.TrimSpace(m.exportInput.Value())
+		if path == "" {
+			return m, nil
+		}
+		m.exporting = false
+		var items []item
+		for _, li := range m.list.VisibleItems() {
+			if it, ok := li.(item); ok {
+				items = append(items, it)
+			}
+		}
+		if err := exportToFile(path, exportFormat(path), items); err != nil {
+			m.cloneError = true
+			m.cloneMsg = tr("Error exporting: %v", err)
+			return m, nil
+		}
+		m.cloneError = false
+		m.cloneMsg = tr("Exported %d repositories to %s", len(items), path)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	return m, cmd
+}
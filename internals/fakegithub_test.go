@@ -0,0 +1,337 @@
+package internals
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// fakeGitHub is an in-memory gitls.API for tests, install it with useFake.
+// It fakes the calls of the repository list and of the screens tested here;
+// missing repositories, teams and traffic answer 404 like the real API. The
+// other methods of the interface fail with errFakeUnsupported.
+type fakeGitHub struct {
+	User      *github.User                       // authenticated user, nil for anonymous
+	Repos     map[string][]*github.Repository    // by owner login
+	Events    map[string][]*github.Event         // by user login
+	Teams     map[string][]*github.Team          // by org login
+	TeamRepos map[string][]*github.Repository    // by org/team slug
+	Contribs  map[string][]*github.Contributor   // by owner/name
+	Notes     []*github.Notification             // the authenticated user's notifications
+	Views     map[string]*github.TrafficViews    // by owner/name
+	Clones    map[string]*github.TrafficClones   // by owner/name
+	Hooks     map[string][]*github.Hook          // by owner/name
+	Pings     []int64                            // hook ids pinged
+	Keys      map[string][]*github.Key           // deploy keys by owner/name, account keys under ""
+	Tags      map[string][]*github.RepositoryTag // by owner/name, newest first
+	Err       error                              // returned by every call when set
+}
+
+// errFakeUnsupported answers the calls no test needs, GraphQL among them,
+// so listings take the REST path.
+var errFakeUnsupported = errors.New("fake: not supported")
+
+func fakeResponse(status int) *github.Response {
+	return &github.Response{
+		Response: &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "api.github.com"}},
+		},
+		Rate: github.Rate{Limit: 5000, Remaining: 5000},
+	}
+}
+
+func fakeNotFound() (*github.Response, error) {
+	resp := fakeResponse(http.StatusNotFound)
+	return resp, &github.ErrorResponse{Response: resp.Response, Message: "Not Found"}
+}
+
+func (f *fakeGitHub) CurrentUser(ctx context.Context) (*github.User, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	if f.User == nil {
+		resp := fakeResponse(http.StatusUnauthorized)
+		return nil, resp, &github.ErrorResponse{Response: resp.Response, Message: "Requires authentication"}
+	}
+	return f.User, fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGitHub) ListRepos(ctx context.Context, user string, opts *github.RepositoryListOptions) ([]*github.Repository, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	own := user == "" && f.User != nil
+	if own {
+		user = f.User.GetLogin()
+	}
+	repos, ok := f.Repos[user]
+	if !ok {
+		resp, err := fakeNotFound()
+		return nil, resp, err
+	}
+	if own && opts.Affiliation == "owner" {
+		var owned []*github.Repository
+		for _, repo := range repos {
+			if repo.GetOwner().GetLogin() == user {
+				owned = append(owned, repo)
+			}
+		}
+		repos = owned
+	}
+	return repos, fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGitHub) UserEvents(ctx context.Context, user string, opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	return f.Events[user], fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGitHub) ListTeams(ctx context.Context, org string, opts *github.ListOptions) ([]*github.Team, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	teams, ok := f.Teams[org]
+	if !ok {
+		resp, err := fakeNotFound()
+		return nil, resp, err
+	}
+	return teams, fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGitHub) ListTeamRepos(ctx context.Context, org, team string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	repos, ok := f.TeamRepos[org+"/"+team]
+	if !ok {
+		resp, err := fakeNotFound()
+		return nil, resp, err
+	}
+	return repos, fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGitHub) ListContributors(ctx context.Context, owner, repo string, opts *github.ListContributorsOptions) ([]*github.Contributor, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	contributors, ok := f.Contribs[owner+"/"+repo]
+	if !ok {
+		resp, err := fakeNotFound()
+		return nil, resp, err
+	}
+	return contributors, fakeResponse(http.StatusOK), nil
+}
+
+// ListNotifications returns unread notifications unless opts.All is set.
+func (f *fakeGitHub) ListNotifications(ctx context.Context, opts *github.NotificationListOptions) ([]*github.Notification, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	var found []*github.Notification
+	for _, n := range f.Notes {
+		if opts.All || n.GetUnread() {
+			found = append(found, n)
+		}
+	}
+	return found, fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGitHub) MarkThreadRead(ctx context.Context, id string) (*github.Response, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	for _, n := range f.Notes {
+		if n.GetID() == id {
+			n.Unread = github.Bool(false)
+			return fakeResponse(http.StatusResetContent), nil
+		}
+	}
+	return fakeNotFound()
+}
+
+func (f *fakeGitHub) TrafficViews(ctx context.Context, owner, repo string) (*github.TrafficViews, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	views, ok := f.Views[owner+"/"+repo]
+	if !ok {
+		resp, err := fakeNotFound()
+		return nil, resp, err
+	}
+	return views, fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGitHub) TrafficClones(ctx context.Context, owner, repo string) (*github.TrafficClones, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	clones, ok := f.Clones[owner+"/"+repo]
+	if !ok {
+		resp, err := fakeNotFound()
+		return nil, resp, err
+	}
+	return clones, fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGitHub) ListHooks(ctx context.Context, owner, repo string) ([]*github.Hook, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	return f.Hooks[owner+"/"+repo], fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGitHub) CreateHook(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	if f.Hooks == nil {
+		f.Hooks = make(map[string][]*github.Hook)
+	}
+	created := *hook
+	created.ID = github.Int64(int64(len(f.Hooks[owner+"/"+repo]) + 1))
+	f.Hooks[owner+"/"+repo] = append(f.Hooks[owner+"/"+repo], &created)
+	return &created, fakeResponse(http.StatusCreated), nil
+}
+
+func (f *fakeGitHub) findHook(owner, repo string, id int64) int {
+	for i, h := range f.Hooks[owner+"/"+repo] {
+		if h.GetID() == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *fakeGitHub) DeleteHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	i := f.findHook(owner, repo, id)
+	if i < 0 {
+		return fakeNotFound()
+	}
+	hooks := f.Hooks[owner+"/"+repo]
+	f.Hooks[owner+"/"+repo] = append(hooks[:i], hooks[i+1:]...)
+	return fakeResponse(http.StatusNoContent), nil
+}
+
+func (f *fakeGitHub) PingHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if f.findHook(owner, repo, id) < 0 {
+		return fakeNotFound()
+	}
+	f.Pings = append(f.Pings, id)
+	return fakeResponse(http.StatusNoContent), nil
+}
+
+func (f *fakeGitHub) ListTags(ctx context.Context, owner, repo string) ([]*github.RepositoryTag, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	return f.Tags[owner+"/"+repo], fakeResponse(http.StatusOK), nil
+}
+
+func keyOwner(owner, repo string) string {
+	if repo == "" {
+		return ""
+	}
+	return owner + "/" + repo
+}
+
+func (f *fakeGitHub) ListKeys(ctx context.Context, owner, repo string) ([]*github.Key, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	return f.Keys[keyOwner(owner, repo)], fakeResponse(http.StatusOK), nil
+}
+
+func (f *fakeGitHub) CreateKey(ctx context.Context, owner, repo string, key *github.Key) (*github.Key, *github.Response, error) {
+	if f.Err != nil {
+		return nil, nil, f.Err
+	}
+	if f.Keys == nil {
+		f.Keys = make(map[string][]*github.Key)
+	}
+	k := keyOwner(owner, repo)
+	created := *key
+	created.ID = github.Int64(int64(len(f.Keys[k]) + 1))
+	f.Keys[k] = append(f.Keys[k], &created)
+	return &created, fakeResponse(http.StatusCreated), nil
+}
+
+func (f *fakeGitHub) DeleteKey(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	k := keyOwner(owner, repo)
+	for i, key := range f.Keys[k] {
+		if key.GetID() == id {
+			f.Keys[k] = append(f.Keys[k][:i], f.Keys[k][i+1:]...)
+			return fakeResponse(http.StatusNoContent), nil
+		}
+	}
+	return fakeNotFound()
+}
+
+// The screens below have no tests, so their calls are not faked.
+
+func (f *fakeGitHub) GetUser(ctx context.Context, login string) (*github.User, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) SearchUsers(ctx context.Context, query string, opts *github.SearchOptions) (*github.UsersSearchResult, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) GetRepo(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) GetTree(ctx context.Context, owner, repo, ref string) (*github.Tree, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) GetFile(ctx context.Context, owner, repo, path string) (*github.RepositoryContent, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) ArchiveLink(ctx context.Context, owner, repo, ref string) (*url.URL, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) SearchRepos(ctx context.Context, query string, opts *github.SearchOptions) (*github.RepositoriesSearchResult, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) ListStarred(ctx context.Context, user string, opts *github.ActivityListStarredOptions) ([]*github.StarredRepository, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) ListGists(ctx context.Context, user string, opts *github.GistListOptions) ([]*github.Gist, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) ListWorkflowRuns(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) LatestRelease(ctx context.Context, owner, repo string) (*github.RepositoryRelease, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) ListLanguages(ctx context.Context, owner, repo string) (map[string]int, *github.Response, error) {
+	return nil, nil, errFakeUnsupported
+}
+
+func (f *fakeGitHub) Query(ctx context.Context, query string, vars map[string]any, out any) (*github.Response, error) {
+	return nil, errFakeUnsupported
+}
@@ -0,0 +1,274 @@
+package internals
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var topicStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("39")).
+	Background(lipgloss.Color("237")).
+	Padding(0, 1)
+
+func topicChips(topics []string) string {
+	chips := make([]string, len(topics))
+	for i, t := range topics {
+		chips[i] = topicStyle.Render(t)
+	}
+	return strings.Join(chips, " ")
+}
+
+// visibilities are cycled with v in authenticated mode; empty shows both.
+var visibilities = []string{"", "private", "public"}
+
+func nextVisibility(current string) string {
+	for i, v := range visibilities {
+		if v == current {
+			return visibilities[(i+1)%len(visibilities)]
+		}
+	}
+	return ""
+}
+
+// activityWindows are cycled with R: repositories pushed within the last
+// week, month or year, or not for over a year. Empty shows all.
+var activityWindows = []struct {
+	name  string
+	label string
+	days  int
+}{
+	{"", "", 0},
+	{"week", "pushed in the last week", 7},
+	{"month", "pushed in the last month", 30},
+	{"year", "pushed in the last year", 365},
+	{"older", "not pushed for over a year", 365},
+}
+
+func nextActivity(current string) string {
+	for i, w := range activityWindows {
+		if w.name == current {
+			return activityWindows[(i+1)%len(activityWindows)].name
+		}
+	}
+	return ""
+}
+
+func activityLabel(name string) string {
+	for _, w := range activityWindows {
+		if w.name == name {
+			return tr(w.label)
+		}
+	}
+	return name
+}
+
+// lastActivity is when it was last pushed to, or updated for items that
+// don't know.
+func lastActivity(it item) time.Time {
+	if !it.pushed.IsZero() {
+		return it.pushed
+	}
+	return it.updated
+}
+
+func matchActivity(it item, window string, now time.Time) bool {
+	for _, w := range activityWindows {
+		if w.name != window || w.days == 0 {
+			continue
+		}
+		recent := lastActivity(it).After(now.AddDate(0, 0, -w.days))
+		return recent == (w.name != "older")
+	}
+	return true
+}
+
+// repoFilter narrows the list on repository attributes, on top of the
+// fuzzy text filter. Items it rejects are kept in repoModel.hidden so
+// clearing the filter brings them back without refetching.
+type repoFilter struct {
+	visibility string
+	topic      string
+	license    string // SPDX id, or "none"
+	activity   string // a window in activityWindows
+}
+
+// String lists the set attributes, e.g. "visibility=private topic=cli".
+func (f repoFilter) String() string {
+	var parts []string
+	for _, kv := range [][2]string{
+		{"visibility", f.visibility}, {"topic", f.topic}, {"license", f.license}, {"activity", f.activity},
+	} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+"="+kv[1])
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func (f repoFilter) match(it item) bool {
+	switch f.visibility {
+	case "private":
+		if !it.private {
+			return false
+		}
+	case "public":
+		if it.private {
+			return false
+		}
+	}
+	if f.topic != "" && !hasTopic(it, f.topic) {
+		return false
+	}
+	if !matchActivity(it, f.activity, time.Now()) {
+		return false
+	}
+	switch {
+	case f.license == "":
+	case strings.EqualFold(f.license, "none"):
+		if it.license != "" {
+			return false
+		}
+	case !strings.EqualFold(f.license, it.license):
+		return false
+	}
+	return true
+}
+
+func hasTopic(it item, topic string) bool {
+	for _, t := range itemTopics(it) {
+		if strings.EqualFold(t, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// allItems returns the shown and the filtered out items.
+func (m repoModel) allItems() []list.Item {
+	items := make([]list.Item, 0, len(m.list.Items())+len(m.hidden))
+	items = append(items, m.list.Items()...)
+	return append(items, m.hidden...)
+}
+
+// setItems sorts items and shows the ones matching the attribute filter.
+func (m *repoModel) setItems(items []list.Item) tea.Cmd {
+	sortItems(items, m.sort)
+	shown := make([]list.Item, 0, len(items))
+	m.hidden = nil
+	for _, li := range items {
+		if it, ok := li.(item); ok && !m.filter.match(it) {
+			m.hidden = append(m.hidden, li)
+			continue
+		}
+		shown = append(shown, li)
+	}
+	return tea.Batch(m.list.SetItems(shown), m.wantEnrich())
+}
+
+func (m repoModel) cycleVisibility() (tea.Model, tea.Cmd) {
+	m.filter.visibility = nextVisibility(m.filter.visibility)
+	m.list.ResetSelected()
+	return m, m.setItems(m.allItems())
+}
+
+func (m repoModel) cycleActivity() (tea.Model, tea.Cmd) {
+	m.filter.activity = nextActivity(m.filter.activity)
+	m.list.ResetSelected()
+	return m, m.setItems(m.allItems())
+}
+
+// visibilityCounts counts private and public repositories, including
+// those hidden by the attribute filter.
+func (m repoModel) visibilityCounts() (private, public int) {
+	for _, li := range m.allItems() {
+		if it, ok := li.(item); ok {
+			if it.private {
+				private++
+			} else {
+				public++
+			}
+		}
+	}
+	return private, public
+}
+
+// topics lists every topic used by the account's repositories, for
+// completing the topic prompt.
+func (m repoModel) topics() []string {
+	seen := make(map[string]bool)
+	var topics []string
+	for _, li := range m.allItems() {
+		it, ok := li.(item)
+		if !ok {
+			continue
+		}
+		for _, t := range itemTopics(it) {
+			if !seen[t] {
+				seen[t] = true
+				topics = append(topics, t)
+			}
+		}
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// licenses lists the licenses in the list plus "none", for completing the
+// license prompt.
+func (m repoModel) licenses() []string {
+	seen := make(map[string]bool)
+	var licenses []string
+	for _, li := range m.allItems() {
+		if it, ok := li.(item); ok && it.license != "" && !seen[it.license] {
+			seen[it.license] = true
+			licenses = append(licenses, it.license)
+		}
+	}
+	sort.Strings(licenses)
+	return append(licenses, "none")
+}
+
+// promptAttr asks for the value of an attribute filter, completing from
+// suggestions with tab.
+func (m repoModel) promptAttr(attr, current string, suggestions []string) (tea.Model, tea.Cmd) {
+	ti := textinput.New()
+	ti.Prompt = tr(attr) + ": "
+	ti.Placeholder = tr("empty to show all")
+	ti.CharLimit = 64
+	ti.ShowSuggestions = true
+	ti.SetSuggestions(suggestions)
+	ti.SetValue(current)
+	ti.Focus()
+	m.attrPrompt = attr
+	m.attrInput = ti
+	return m, textinput.Blink
+}
+
+func (m repoModel) updateAttrPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.attrPrompt = ""
+		return m, nil
+	case tea.KeyEnter:
+		value := strings.TrimSpace(m.attrInput.Value())
+		switch m.attrPrompt {
+		case "topic":
+			m.filter.topic = value
+		case "license":
+			m.filter.license = value
+		}
+		m.attrPrompt = ""
+		m.list.ResetSelected()
+		return m, m.setItems(m.allItems())
+	}
+
+	var cmd tea.Cmd
+	m.attrInput, cmd = m.attrInput.Update(msg)
+	return m, cmd
+}
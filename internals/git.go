@@ -0,0 +1,78 @@
+package internals
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+)
+
+// gitBinary is the git executable to run, "git" from PATH unless
+// configured.
+func gitBinary() string {
+	if cfg.GitPath != "" {
+		return expandHome(cfg.GitPath)
+	}
+	return "git"
+}
+
+// gitEnv is the configured environment for git on top of ours. Variables
+// already set in the environment win over ssh_command so a one-off
+// GIT_SSH_COMMAND still works.
+func gitEnv() []string {
+	var env []string
+	if cfg.SSHCommand != "" && os.Getenv("GIT_SSH_COMMAND") == "" {
+		env = append(env, "GIT_SSH_COMMAND="+cfg.SSHCommand)
+	}
+	keys := make([]string, 0, len(cfg.GitEnv))
+	for k := range cfg.GitEnv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		env = append(env, k+"="+cfg.GitEnv[k])
+	}
+	return env
+}
+
+func gitRunner() gitls.Git {
+	return gitls.Git{Path: gitBinary(), Env: gitEnv(), Rewrites: urlRewrites(), Logger: logger}
+}
+
+func runGit(args ...string) ([]byte, error) {
+	return runGitEnv(nil, args...)
+}
+
+// runGitEnv runs git with extra environment variables on top of ours.
+func runGitEnv(env []string, args ...string) ([]byte, error) {
+	return gitRunner().Run(context.Background(), env, args...)
+}
+
+// gitFailure explains a failed clone of url into dest and says what to do
+// about it, naming the host url_rewrites sent git to. Failures git's output
+// doesn't explain are shown as they are.
+func gitFailure(err error, url, dest string) string {
+	var ge *gitls.GitError
+	if !errors.As(err, &ge) {
+		return err.Error()
+	}
+	url = rewriteURL(url)
+	host, _, _ := strings.Cut(remoteKey(url), "/")
+	switch ge.Kind {
+	case gitls.GitErrAuth:
+		if strings.HasPrefix(url, "http") {
+			return tr("%s rejected the credentials. Run `gitls login` to store a token, or check your git credential helper.", host)
+		}
+		return tr("%s rejected your SSH key. Check it with `ssh -T git@%s` and make sure it's added to your account.", host, host)
+	case gitls.GitErrHostKey:
+		return tr("The SSH host key of %s is unknown or has changed. Verify it, then accept it by running `ssh -T git@%s` once.", host, host)
+	case gitls.GitErrDiskFull:
+		return tr("No space left to clone into %s/. Free some space or point clone_dir elsewhere.", dest)
+	case gitls.GitErrNotFound:
+		return tr("%s doesn't exist or your credentials can't see it. Private repositories need a token with the repo scope (`gitls login`).", strings.TrimPrefix(remoteKey(url), host+"/"))
+	}
+	return err.Error()
+}
@@ -0,0 +1,76 @@
+package internals
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	"github.com/google/go-github/v50/github"
+)
+
+type fetchInfo struct {
+	login        string   // authenticated user, empty when anonymous
+	scopes       []string // classic token scopes, empty for fine-grained tokens
+	tokenWarning string
+	rate         github.Rate
+	cachedAt     time.Time // listing served from cache while rate limited
+}
+
+func githubToken() string {
+	return activeProfile.token()
+}
+
+func clientOptions(token string) gitls.Options {
+	return gitls.Options{
+		Provider:   gitls.Provider(activeProfile.provider()),
+		Token:      token,
+		BaseURL:    activeProfile.BaseURL,
+		HTTPClient: &http.Client{Transport: loggingTransport{next: http.DefaultTransport}},
+		Logger:     logger,
+	}
+}
+
+// newTokenAPI returns the GitHub API for token, anonymous when it is empty.
+// Everything goes through it, so tests can swap in a fakeGitHub.
+var newTokenAPI = func(token string) gitls.API {
+	return gitls.New(clientOptions(token)).API()
+}
+
+// newAPI returns the GitHub API authenticated as the active profile.
+func newAPI() gitls.API {
+	return newTokenAPI(githubToken())
+}
+
+// newClient returns the library client for the active profile.
+func newClient() *gitls.Client {
+	opts := clientOptions(githubToken())
+	opts.NewAPI = newTokenAPI
+	return gitls.New(opts)
+}
+
+func fetchRepos(username string) ([]*github.Repository, fetchInfo, error) {
+	if reset, limited := rateLimited(); limited {
+		return fromCache(username, reset)
+	}
+	client := newClient()
+	repos, li, err := client.ListRepos(context.Background(), username)
+	if err != nil {
+		noteRateErr(err)
+		if reset, limited := rateLimited(); limited {
+			return fromCache(username, reset)
+		}
+	}
+	info := fetchInfo{login: li.Login, scopes: li.Scopes, tokenWarning: li.TokenWarning, rate: li.Rate}
+	for _, repo := range repos {
+		if meta, ok := client.Meta(repo.GetFullName()); ok {
+			metaCache.Store(repo.GetFullName(), meta)
+		}
+	}
+	if err == nil {
+		recordAccount(username)
+		recordAction(sessionAction{Action: "user", User: username})
+		cacheRepos(username, repos)
+	}
+	return repos, info, err
+}
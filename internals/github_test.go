@@ -0,0 +1,131 @@
+package internals
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v50/github"
+)
+
+// useFake routes every API call to fake and keeps state, config and clones
+// in temporary directories.
+func useFake(t *testing.T, fake *fakeGitHub) {
+	t.Helper()
+	prevAPI, prevProfile := newTokenAPI, activeProfile
+	t.Cleanup(func() { newTokenAPI, activeProfile = prevAPI, prevProfile })
+	newTokenAPI = func(string) gitls.API { return fake }
+	t.Setenv("GITHUB_TOKEN", "test")
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	activeProfile = profile{CloneDir: t.TempDir()}
+}
+
+func fakeRepo(owner, name, cloneURL string) *github.Repository {
+	return &github.Repository{
+		Name:     github.String(name),
+		FullName: github.String(owner + "/" + name),
+		CloneURL: github.String(cloneURL),
+		Owner:    &github.User{Login: github.String(owner)},
+	}
+}
+
+// runUntil runs cmd and the commands it batches until one produces a T.
+func runUntil[T tea.Msg](t *testing.T, cmd tea.Cmd) T {
+	t.Helper()
+	queue := []tea.Cmd{cmd}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if c == nil {
+			continue
+		}
+		switch msg := c().(type) {
+		case T:
+			return msg
+		case tea.BatchMsg:
+			queue = append(queue, msg...)
+		}
+	}
+	var zero T
+	t.Fatalf("no %T among the messages", zero)
+	return zero
+}
+
+func TestFetchRepos(t *testing.T) {
+	useFake(t, &fakeGitHub{Repos: map[string][]*github.Repository{
+		"bob": {fakeRepo("bob", "a", ""), fakeRepo("bob", "b", "")},
+	}})
+
+	repos, _, err := fetchRepos("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 2 || repos[0].GetName() != "a" || repos[1].GetName() != "b" {
+		t.Errorf("fetchRepos(bob) = %v, want a and b", repos)
+	}
+	if _, _, err := fetchRepos("nobody"); err == nil {
+		t.Error("fetchRepos(nobody) succeeded, want an error")
+	}
+}
+
+func TestRepoModelClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	src := filepath.Join(t.TempDir(), "a")
+	for _, args := range [][]string{{"init", "-q", src}, {"-C", src, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"}} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	useFake(t, &fakeGitHub{Repos: map[string][]*github.Repository{
+		"bob": {fakeRepo("bob", "a", "file://"+src)},
+	}})
+
+	m, ok := initialModel("bob").(repoModel)
+	if !ok {
+		t.Fatal("initialModel(bob) is not a repository list")
+	}
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	checked := runUntil[lfsCheckedMsg](t, cmd)
+	model, cmd = model.Update(checked)
+	finished := runUntil[cloneFinishedMsg](t, cmd)
+	if finished.err != nil {
+		t.Fatal(finished.err)
+	}
+	model, _ = model.Update(finished)
+
+	m = model.(repoModel)
+	want := filepath.Join(activeProfile.CloneDir, "a")
+	if m.lastClone != want {
+		t.Errorf("lastClone = %q, want %q", m.lastClone, want)
+	}
+	if _, err := os.Stat(filepath.Join(want, ".git")); err != nil {
+		t.Errorf("no clone in %s: %v", want, err)
+	}
+	if m.cloneError || !strings.Contains(m.cloneMsg, want) {
+		t.Errorf("status %q (error %v), want success mentioning %s", m.cloneMsg, m.cloneError, want)
+	}
+}
+
+func TestRepoModelEmptyAccount(t *testing.T) {
+	useFake(t, &fakeGitHub{Repos: map[string][]*github.Repository{"empty": {}}})
+
+	m, ok := initialModel("empty").(repoModel)
+	if !ok {
+		t.Fatal("initialModel(empty) is not a repository list")
+	}
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = model.(repoModel)
+	if !m.emptyAccount() {
+		t.Fatal("emptyAccount() = false for an account without repositories")
+	}
+	if view := m.View(); !strings.Contains(view, "empty has no public repositories.") {
+		t.Errorf("View() doesn't explain the empty account:\n%s", view)
+	}
+}
@@ -0,0 +1,219 @@
+package internals
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const historyFile = "history.jsonl"
+
+type historyEntry struct {
+	Repo  string    `json:"repo"`
+	URL   string    `json:"url"`
+	Dir   string    `json:"dir"`
+	Time  time.Time `json:"time"`
+	Error string    `json:"error,omitempty"`
+}
+
+func (e historyEntry) Title() string {
+	if e.Error != "" {
+		return e.Repo + " " + errorStyle.Render(glyph("✗ ", "")+tr("failed"))
+	}
+	return e.Repo + " " + successStyle.Render(glyph("✓ ", "")+tr("cloned"))
+}
+
+func (e historyEntry) Description() string {
+	where := e.Dir
+	if where == "" {
+		where = e.URL
+	}
+	return e.Time.Local().Format("2006-01-02 15:04") + " · " + where
+}
+
+func (e historyEntry) FilterValue() string { return e.Repo }
+
+var historyMu sync.Mutex
+
+// recordClone appends a clone attempt to the history file. Failing to write
+// history must never fail the clone itself, so errors are only logged.
+func recordClone(repo, url, dir string, cloneErr error) {
+	entry := historyEntry{Repo: repo, URL: url, Time: time.Now()}
+	if dir != "" {
+		entry.Dir = absCloneDir(dir)
+	}
+	if cloneErr != nil {
+		entry.Error = cloneErr.Error()
+	}
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	if err := appendHistory(entry); err != nil {
+		logger.Warn("could not record clone history", "err", err)
+	}
+}
+
+func appendHistory(entry historyEntry) error {
+	dir, err := stateDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, historyFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(entry)
+}
+
+// readHistory returns all recorded clones, newest first.
+func readHistory() ([]historyEntry, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(dir, historyFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []historyEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e historyEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	slices.Reverse(entries)
+	return entries, scanner.Err()
+}
+
+// PrintHistory writes the clone history to stdout.
+func PrintHistory() error {
+	entries, err := readHistory()
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		result := "ok"
+		if e.Error != "" {
+			result = "failed: " + strings.SplitN(e.Error, "\n", 2)[0]
+		}
+		fmt.Printf("%s  %-30s  %s  %s\n", e.Time.Local().Format("2006-01-02 15:04"), e.Repo, e.Dir, result)
+	}
+	return nil
+}
+
+type historyModel struct {
+	list list.Model
+	err  string
+}
+
+func newHistoryModel() historyModel {
+	entries, err := readHistory()
+	items := make([]list.Item, len(entries))
+	for i, e := range entries {
+		items[i] = e
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	localizeList(&l)
+	l.Title = tr("Clone history")
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", tr("open shell in clone"))),
+		}
+	}
+
+	m := historyModel{list: l}
+	if err != nil {
+		m.err = err.Error()
+	}
+	return m
+}
+
+type shellExitedMsg struct{ err error }
+
+func (m historyModel) crumbs() []string { return []string{"history"} }
+
+func (m historyModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m historyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			if m.list.FilterState() == list.Unfiltered {
+				return m, goBack
+			}
+		case "enter":
+			e, ok := m.list.SelectedItem().(historyEntry)
+			if !ok {
+				return m, nil
+			}
+			if _, err := os.Stat(e.Dir); e.Dir == "" || err != nil {
+				m.err = fmt.Sprintf("%s no longer exists", e.Dir)
+				return m, nil
+			}
+			m.err = ""
+			shell := os.Getenv("SHELL")
+			if shell == "" {
+				shell = "/bin/sh"
+			}
+			c := exec.Command(shell)
+			c.Dir = e.Dir
+			return m, tea.ExecProcess(c, func(err error) tea.Msg { return shellExitedMsg{err} })
+		}
+	case shellExitedMsg:
+		if msg.err != nil {
+			m.err = msg.err.Error()
+		}
+		return m, nil
+	case tea.WindowSizeMsg:
+		h, v := normalStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v-1)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m historyModel) View() string {
+	body := m.list.View()
+	if m.err != "" {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, errorStyle.Render(m.err))
+	}
+	return normalStyle.Render(body)
+}
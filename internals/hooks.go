@@ -0,0 +1,164 @@
+package internals
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Hooks are executables in the hooks directory named after the event they
+// handle, like git hooks.
+const (
+	hookPreClone  = "pre-clone"
+	hookPostClone = "post-clone"
+	hookOnSelect  = "on-select"
+)
+
+const (
+	hookTimeout     = time.Minute
+	selectHookDelay = 300 * time.Millisecond
+)
+
+// hookPayload describes the repository a hook runs for. It is written to
+// the hook's stdin as JSON and the main fields are also set as GITLS_*
+// environment variables.
+type hookPayload struct {
+	Event         string   `json:"event"`
+	Repo          string   `json:"repo"` // owner/name
+	Owner         string   `json:"owner"`
+	Name          string   `json:"name"`
+	URL           string   `json:"url"`
+	Dir           string   `json:"dir,omitempty"` // clone destination
+	Description   string   `json:"description,omitempty"`
+	Language      string   `json:"language,omitempty"`
+	DefaultBranch string   `json:"default_branch,omitempty"`
+	Topics        []string `json:"topics,omitempty"`
+	Stars         int      `json:"stars"`
+	Private       bool     `json:"private"`
+	Fork          bool     `json:"fork"`
+	Archived      bool     `json:"archived"`
+}
+
+func newHookPayload(event string, it item, dir string) hookPayload {
+	return hookPayload{
+		Event:         event,
+		Repo:          it.owner + "/" + it.name,
+		Owner:         it.owner,
+		Name:          it.name,
+		URL:           it.url,
+		Dir:           dir,
+		Description:   it.description,
+		Language:      it.language,
+		DefaultBranch: it.defaultBranch,
+		Topics:        itemTopics(it),
+		Stars:         it.stars,
+		Private:       it.private,
+		Fork:          it.fork,
+		Archived:      it.archived,
+	}
+}
+
+func (p hookPayload) env() []string {
+	return []string{
+		"GITLS_EVENT=" + p.Event,
+		"GITLS_REPO=" + p.Repo,
+		"GITLS_OWNER=" + p.Owner,
+		"GITLS_NAME=" + p.Name,
+		"GITLS_URL=" + p.URL,
+		"GITLS_DIR=" + p.Dir,
+		"GITLS_STARS=" + strconv.Itoa(p.Stars),
+		"GITLS_PRIVATE=" + strconv.FormatBool(p.Private),
+		"GITLS_FORK=" + strconv.FormatBool(p.Fork),
+	}
+}
+
+func hooksDir() (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "hooks"), nil
+}
+
+// hookPath is the executable for event, or "" if none is installed.
+// Windows has no executable bit, so there LookPath wants an extension from
+// PATHEXT instead, e.g. pre-clone.cmd.
+func hookPath(event string) string {
+	dir, err := hooksDir()
+	if err != nil {
+		return ""
+	}
+	path, err := exec.LookPath(filepath.Join(dir, event))
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// runHook runs the hook for p.Event, if there is one. A hook that exits
+// non-zero fails with its output, which for pre-clone cancels the clone.
+func runHook(p hookPayload) error {
+	path := hookPath(p.Event)
+	if path == "" {
+		return nil
+	}
+	input, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	c := exec.CommandContext(ctx, path)
+	c.Env = append(os.Environ(), p.env()...)
+	c.Stdin = bytes.NewReader(input)
+	out, err := c.CombinedOutput()
+	logger.Debug("hook", "event", p.Event, "repo", p.Repo, "err", err)
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s hook: %w: %s", p.Event, err, msg)
+		}
+		return fmt.Errorf("%s hook: %w", p.Event, err)
+	}
+	return nil
+}
+
+type selectHookMsg struct{ repo string }
+
+// wantSelectHook runs the on-select hook once a repository has stayed
+// selected for selectHookDelay, so scrolling doesn't start a process per
+// row.
+func (m repoModel) wantSelectHook() tea.Cmd {
+	it, ok := m.list.SelectedItem().(item)
+	if !ok || hookPath(hookOnSelect) == "" {
+		return nil
+	}
+	repo := it.owner + "/" + it.name
+	return tea.Tick(selectHookDelay, func(time.Time) tea.Msg { return selectHookMsg{repo: repo} })
+}
+
+func (m repoModel) runSelectHook(msg selectHookMsg) tea.Cmd {
+	it, ok := m.list.SelectedItem().(item)
+	if !ok || it.owner+"/"+it.name != msg.repo {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := runHook(newHookPayload(hookOnSelect, it, "")); err != nil {
+			logger.Warn("on-select hook failed", "repo", msg.repo, "err", err)
+		}
+		return nil
+	}
+}
+
+// selectionChanged starts the work that follows the selection.
+func (m repoModel) selectionChanged() tea.Cmd {
+	return tea.Batch(m.wantCIStatus(), m.wantEnrich(), m.wantSelectHook(), m.setWindowTitle())
+}
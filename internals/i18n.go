@@ -0,0 +1,128 @@
+package internals
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// Translations are JSON objects mapping the English text of a message, as
+// passed to tr, to its translation. Messages a catalog lacks stay English.
+//
+//go:embed locales/*.json
+var localeFS embed.FS
+
+var (
+	locale  = "en"
+	catalog map[string]string
+)
+
+// tr translates msg into the active locale and, given args, formats it
+// like fmt.Sprintf. Format verbs must survive translation unchanged; use
+// %[n]v when a language needs them in another order.
+func tr(msg string, args ...any) string {
+	if t, ok := catalog[msg]; ok && t != "" {
+		msg = t
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// SelectLocale picks the UI language from the locale config key, then
+// LC_ALL, LC_MESSAGES and LANG. A language without a catalog falls back
+// to English.
+func SelectLocale() {
+	name := cfg.Locale
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if name != "" {
+			break
+		}
+		name = os.Getenv(env)
+	}
+	locale, catalog = "en", nil
+	for _, cand := range localeCandidates(name) {
+		c, err := loadCatalog(cand)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			logger.Warn("could not load translations", "locale", cand, "err", err)
+			return
+		}
+		locale, catalog = cand, c
+		logger.Debug("locale selected", "locale", cand, "messages", len(c))
+		return
+	}
+}
+
+// Locale is the active UI language, "en" when nothing is translated.
+func Locale() string {
+	return locale
+}
+
+// localeCandidates turns a POSIX locale such as "pt_BR.UTF-8@euro" into
+// the catalogs to try, most specific first: pt_BR, then pt.
+func localeCandidates(name string) []string {
+	name, _, _ = strings.Cut(name, ".")
+	name, _, _ = strings.Cut(name, "@")
+	name = strings.ReplaceAll(name, "-", "_")
+	if name == "" || name == "C" || name == "POSIX" {
+		return nil
+	}
+	lang, region, ok := strings.Cut(name, "_")
+	lang = strings.ToLower(lang)
+	if lang == "en" {
+		return nil
+	}
+	if ok {
+		return []string{lang + "_" + strings.ToUpper(region), lang}
+	}
+	return []string{lang}
+}
+
+// loadCatalog reads a locale from the user's config dir, so translators can
+// try a catalog without rebuilding, then from the ones built in.
+func loadCatalog(name string) (map[string]string, error) {
+	var raw []byte
+	err := fs.ErrNotExist
+	if dir, derr := os.UserConfigDir(); derr == nil {
+		raw, err = os.ReadFile(filepath.Join(dir, "gitls", "locales", name+".json"))
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		raw, err = localeFS.ReadFile("locales/" + name + ".json")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c map[string]string
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return c, nil
+}
+
+// localizeList translates the help and prompts bubbles' list has built in.
+func localizeList(l *list.Model) {
+	km := &l.KeyMap
+	for _, b := range []*key.Binding{
+		&km.CursorUp, &km.CursorDown, &km.PrevPage, &km.NextPage,
+		&km.GoToStart, &km.GoToEnd, &km.Filter, &km.ClearFilter,
+		&km.CancelWhileFiltering, &km.AcceptWhileFiltering,
+		&km.ShowFullHelp, &km.CloseFullHelp, &km.Quit, &km.ForceQuit,
+	} {
+		h := b.Help()
+		b.SetHelp(h.Key, tr(h.Desc))
+	}
+	l.FilterInput.Prompt = tr("Filter: ")
+	l.SetStatusBarItemName(tr("item"), tr("items"))
+}
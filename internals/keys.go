@@ -0,0 +1,402 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v50/github"
+)
+
+type sshKey struct {
+	id       int64
+	title    string
+	key      string
+	deploy   bool
+	readOnly bool
+	created  time.Time
+}
+
+func (k sshKey) Title() string {
+	if !k.deploy {
+		return k.title
+	}
+	if k.readOnly {
+		return k.title + " " + badgeStyle.Render("read-only")
+	}
+	return k.title + " " + badgeStyle.Render("read-write")
+}
+
+func (k sshKey) Description() string {
+	desc := keySummary(k.key)
+	if !k.created.IsZero() {
+		desc += " · added " + relativeTime(k.created)
+	}
+	return desc
+}
+
+func (k sshKey) FilterValue() string { return k.title }
+
+// keySummary shortens a public key to its type and last characters, enough
+// to tell keys apart.
+func keySummary(pub string) string {
+	fields := strings.Fields(pub)
+	if len(fields) < 2 {
+		return pub
+	}
+	data := fields[1]
+	if len(data) > 16 {
+		data = "…" + data[len(data)-16:]
+	}
+	return fields[0] + " " + data
+}
+
+// readPublicKey reads an OpenSSH public key file, dropping the comment.
+func readPublicKey(path string) (string, error) {
+	data, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 || (!strings.HasPrefix(fields[0], "ssh-") && !strings.HasPrefix(fields[0], "ecdsa-") && !strings.HasPrefix(fields[0], "sk-")) {
+		return "", fmt.Errorf("%s is not an OpenSSH public key", path)
+	}
+	return fields[0] + " " + fields[1], nil
+}
+
+// keygen prepares ssh-keygen to write a new ed25519 key pair to path.
+// Deploy keys are used by machines and get no passphrase; for account keys
+// ssh-keygen asks for one.
+func keygen(path, comment string, passphrase bool) (*exec.Cmd, error) {
+	path = expandHome(path)
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%s already exists", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	args := []string{"-t", "ed25519", "-C", comment, "-f", path}
+	if !passphrase {
+		args = append([]string{"-q", "-N", ""}, args...)
+	}
+	return exec.Command("ssh-keygen", args...), nil
+}
+
+// generateKeypair writes a new passphrase-less ed25519 key pair with
+// ssh-keygen and returns the public half.
+func generateKeypair(path, comment string) (string, error) {
+	c, err := keygen(path, comment, false)
+	if err != nil {
+		return "", err
+	}
+	out, err := c.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("ssh-keygen: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return readPublicKey(expandHome(path) + ".pub")
+}
+
+func keyTitle() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return "gitls"
+	}
+	return "gitls@" + host
+}
+
+type keysFetchedMsg struct {
+	keys []list.Item
+	err  error
+}
+
+// keyGeneratedMsg follows ssh-keygen run in the terminal for an account
+// key.
+type keyGeneratedMsg struct {
+	path string
+	err  error
+}
+
+type keyDoneMsg struct {
+	status string
+	err    error
+}
+
+// keysModel manages the deploy keys of a repository, or your account's SSH
+// keys; tab switches between the two.
+type keysModel struct {
+	it            item
+	account       bool
+	list          list.Model
+	prompting     string // "add" or "generate" while asking for a path
+	input         textinput.Model
+	readWrite     bool // new deploy keys get write access
+	confirmDelete bool
+	loading       bool
+	status        string
+	err           error
+}
+
+func newKeysModel(it item) keysModel {
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	localizeList(&l)
+	// g generates and d deletes, so the list keeps only its other keys
+	l.KeyMap.GoToStart = key.NewBinding(key.WithKeys("home"), key.WithHelp("home", tr("go to start")))
+	l.KeyMap.NextPage = key.NewBinding(key.WithKeys("right", "l", "pgdown", "f"), key.WithHelp("→/l/pgdn", tr("next page")))
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", tr("deploy/account keys"))),
+			key.NewBinding(key.WithKeys("a"), key.WithHelp("a", tr("add"))),
+			key.NewBinding(key.WithKeys("g"), key.WithHelp("g", tr("generate"))),
+			key.NewBinding(key.WithKeys("d"), key.WithHelp("d", tr("delete"))),
+		}
+	}
+	m := keysModel{it: it, list: l, loading: true}
+	m.setTitle()
+	return m
+}
+
+func (m *keysModel) setTitle() {
+	if m.account {
+		m.list.Title = tr("Your SSH keys")
+	} else {
+		m.list.Title = tr("%s/%s deploy keys", m.it.owner, m.it.name)
+	}
+}
+
+// target is the owner and repository the key API calls act on; an empty
+// repository means the account.
+func (m keysModel) target() (string, string) {
+	if m.account {
+		return "", ""
+	}
+	return m.it.owner, m.it.name
+}
+
+func (m keysModel) crumbs() []string { return []string{"keys"} }
+
+func (m keysModel) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m keysModel) fetch() tea.Cmd {
+	owner, repo := m.target()
+	return func() tea.Msg {
+		if activeProfile.provider() != providerGitHub {
+			return keysFetchedMsg{err: fmt.Errorf("keys are only supported for GitHub profiles")}
+		}
+		keys, _, err := newAPI().ListKeys(context.Background(), owner, repo)
+		if err != nil {
+			return keysFetchedMsg{err: fmt.Errorf("failed to fetch keys: %w", err)}
+		}
+		items := make([]list.Item, len(keys))
+		for i, k := range keys {
+			items[i] = sshKey{
+				id:       k.GetID(),
+				title:    k.GetTitle(),
+				key:      k.GetKey(),
+				deploy:   repo != "",
+				readOnly: k.GetReadOnly(),
+				created:  k.GetCreatedAt().Time,
+			}
+		}
+		return keysFetchedMsg{keys: items}
+	}
+}
+
+// upload sends the public key at path, first generating the pair when
+// generate is set. generated means it was just written to path.
+func (m keysModel) upload(path string, generate, generated bool) tea.Cmd {
+	owner, repo := m.target()
+	readOnly := !m.readWrite
+	return func() tea.Msg {
+		var pub string
+		var err error
+		switch {
+		case generate:
+			pub, err = generateKeypair(path, keyTitle())
+		case generated:
+			pub, err = readPublicKey(path + ".pub")
+		default:
+			pub, err = readPublicKey(path)
+		}
+		if err != nil {
+			return keyDoneMsg{err: err}
+		}
+		k := &github.Key{Title: github.String(keyTitle()), Key: github.String(pub)}
+		if repo != "" {
+			k.ReadOnly = github.Bool(readOnly)
+		}
+		if _, _, err := newAPI().CreateKey(context.Background(), owner, repo, k); err != nil {
+			return keyDoneMsg{err: fmt.Errorf("failed to upload key: %w", err)}
+		}
+		if generate || generated {
+			return keyDoneMsg{status: tr("Generated %s and uploaded the public key", expandHome(path))}
+		}
+		return keyDoneMsg{status: tr("Uploaded %s", path)}
+	}
+}
+
+// generateInTerminal runs ssh-keygen in the terminal so it can ask for the
+// passphrase of a new account key.
+func generateInTerminal(path string) tea.Cmd {
+	c, err := keygen(path, keyTitle(), true)
+	if err != nil {
+		return func() tea.Msg { return keyGeneratedMsg{err: err} }
+	}
+	return tea.ExecProcess(c, func(err error) tea.Msg { return keyGeneratedMsg{path: path, err: err} })
+}
+
+func (m keysModel) remove(k sshKey) tea.Cmd {
+	owner, repo := m.target()
+	return func() tea.Msg {
+		if _, err := newAPI().DeleteKey(context.Background(), owner, repo, k.id); err != nil {
+			return keyDoneMsg{err: fmt.Errorf("failed to delete key: %w", err)}
+		}
+		return keyDoneMsg{status: tr("Deleted %s", k.title)}
+	}
+}
+
+func (m keysModel) prompt(kind, value string) (tea.Model, tea.Cmd) {
+	m.input = textinput.New()
+	m.input.CharLimit = 256
+	if kind == "generate" {
+		m.input.Prompt = tr("new key file: ")
+	} else {
+		m.input.Prompt = tr("public key file: ")
+	}
+	m.input.SetValue(value)
+	m.input.Focus()
+	m.prompting = kind
+	return m, textinput.Blink
+}
+
+func (m keysModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case keysFetchedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+		}
+		return m, m.list.SetItems(msg.keys)
+	case keyGeneratedMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("ssh-keygen: %w", msg.err)
+			return m, nil
+		}
+		return m, m.upload(msg.path, false, true)
+	case keyDoneMsg:
+		m.status, m.err = msg.status, msg.err
+		return m, m.fetch()
+	case tea.KeyMsg:
+		if m.prompting != "" {
+			return m.updatePrompt(msg)
+		}
+		if m.confirmDelete {
+			m.confirmDelete = false
+			k, ok := m.list.SelectedItem().(sshKey)
+			if ok && msg.String() == "y" {
+				return m, m.remove(k)
+			}
+			return m, nil
+		}
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			if m.list.FilterState() == list.Unfiltered {
+				return m, goBack
+			}
+		case "tab":
+			m.account = !m.account
+			m.setTitle()
+			m.loading, m.status, m.err = true, "", nil
+			return m, tea.Batch(m.list.SetItems(nil), m.fetch())
+		case "a":
+			return m.prompt("add", "~/.ssh/id_ed25519.pub")
+		case "g":
+			path := "~/.ssh/id_ed25519_gitls"
+			if !m.account {
+				path = "~/.ssh/" + m.it.name + "_deploy_ed25519"
+			}
+			return m.prompt("generate", path)
+		case "w":
+			if !m.account {
+				m.readWrite = !m.readWrite
+			}
+			return m, nil
+		case "d":
+			if _, ok := m.list.SelectedItem().(sshKey); ok {
+				m.confirmDelete = true
+			}
+			return m, nil
+		}
+	case tea.WindowSizeMsg:
+		h, v := normalStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v-3)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m keysModel) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.prompting = ""
+		return m, nil
+	case tea.KeyEnter:
+		path := strings.TrimSpace(m.input.Value())
+		if path == "" {
+			return m, nil
+		}
+		generate := m.prompting == "generate"
+		m.prompting = ""
+		m.err = nil
+		if generate && m.account {
+			return m, generateInTerminal(path)
+		}
+		return m, m.upload(path, generate, false)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m keysModel) View() string {
+	body := m.list.View()
+	switch {
+	case m.prompting != "":
+		body = lipgloss.JoinVertical(lipgloss.Left, body, m.input.View())
+	case m.confirmDelete:
+		k, _ := m.list.SelectedItem().(sshKey)
+		body = lipgloss.JoinVertical(lipgloss.Left, body, errorStyle.Render(tr("Delete %s? (y/n)", k.title)))
+	case m.loading:
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("Loading keys..."))
+	case !m.account:
+		access := tr("read-only")
+		if m.readWrite {
+			access = tr("read-write")
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, body, badgeStyle.Render(tr("new deploy keys are %s (w: toggle)", access)))
+	}
+	if m.err != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, errorStyle.Render(m.err.Error()))
+	} else if m.status != "" {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, successStyle.Render(m.status))
+	}
+	return normalStyle.Render(body)
+}
@@ -0,0 +1,31 @@
+package internals
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+)
+
+// DefaultLayout is the --layout default for batch commands.
+func DefaultLayout() string {
+	if cfg.Layout == string(gitls.LayoutGhq) {
+		return string(gitls.LayoutGhq)
+	}
+	return string(gitls.LayoutFlat)
+}
+
+// ghqRoot is where ghq keeps its clones: ghq.root from the git config or
+// ~/ghq.
+func ghqRoot() string {
+	if out, err := runGit("config", "--get", "ghq.root"); err == nil {
+		if root := strings.TrimSpace(string(out)); root != "" {
+			return expandHome(root)
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, "ghq")
+	}
+	return "ghq"
+}
@@ -0,0 +1,34 @@
+package internals
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type lfsCheckedMsg struct {
+	it  item
+	lfs bool
+}
+
+// checkLFS looks for "filter=lfs" in the repository's root .gitattributes.
+func checkLFS(it item) tea.Cmd {
+	return func() tea.Msg {
+		if activeProfile.provider() != providerGitHub {
+			return lfsCheckedMsg{it: it}
+		}
+		ctx := context.Background()
+		file, _, err := newAPI().GetFile(ctx, it.owner, it.name, ".gitattributes")
+		if err != nil || file == nil {
+			logger.Debug("no .gitattributes", "repo", it.name, "err", err)
+			return lfsCheckedMsg{it: it}
+		}
+
+		content, err := file.GetContent()
+		if err != nil {
+			return lfsCheckedMsg{it: it}
+		}
+		return lfsCheckedMsg{it: it, lfs: strings.Contains(content, "filter=lfs")}
+	}
+}
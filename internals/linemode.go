@@ -0,0 +1,326 @@
+package internals
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+var lineMode bool
+
+// SetLineMode replaces the full-screen list with line-by-line prompts and
+// numbered choices, for screen readers and dumb terminals. Other screens
+// run inline instead of on the alternate screen.
+func SetLineMode(on bool) {
+	lineMode = on
+}
+
+const linePageSize = 20
+
+// lineUI browses an account by printing numbered pages and reading one
+// command per line.
+type lineUI struct {
+	in    *bufio.Reader
+	out   io.Writer
+	user  string
+	items []item // sorted
+	shown []item // matching query
+	query string
+	sort  sortMode
+	page  int
+}
+
+func newLineUI(in io.Reader, out io.Writer) *lineUI {
+	return &lineUI{in: bufio.NewReader(in), out: out}
+}
+
+// lineRun browses user, or asks for an account when it is empty.
+func lineRun(user string) {
+	ui := newLineUI(os.Stdin, os.Stdout)
+	if err := ui.run(user); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func (ui *lineUI) printf(format string, args ...any) {
+	fmt.Fprintf(ui.out, format, args...)
+}
+
+// ask prints prompt and reads a line. io.EOF means the input was closed.
+func (ui *lineUI) ask(prompt string) (string, error) {
+	ui.printf("%s", prompt)
+	line, err := ui.in.ReadString('\n')
+	if err != nil && (line == "" || err != io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func (ui *lineUI) run(user string) error {
+	for user == "" {
+		answer, err := ui.ask(tr("GitHub username: "))
+		if err != nil {
+			return nil
+		}
+		user = answer
+	}
+	if err := ui.load(user); err != nil {
+		return err
+	}
+	ui.printPage()
+	for {
+		line, err := ui.ask("> ")
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if quit := ui.command(line); quit {
+			return nil
+		}
+	}
+}
+
+// load lists user's repositories, keeping the current list when that
+// fails.
+func (ui *lineUI) load(user string) error {
+	ui.printf("%s\n", tr("Looking up %s...", user))
+	repos, _, err := fetchRepos(user)
+	if err != nil {
+		return err
+	}
+	ui.user = user
+	ui.items = make([]item, len(repos))
+	for i, repo := range repos {
+		ui.items[i] = newItem(repo)
+	}
+	ui.query = ""
+	ui.resort()
+	ui.printf("%s\n", tr("%s has %d repositories. Type h for help.", user, len(ui.items)))
+	return nil
+}
+
+func (ui *lineUI) resort() {
+	items := make([]list.Item, len(ui.items))
+	for i, it := range ui.items {
+		items[i] = it
+	}
+	sortItems(items, ui.sort)
+	ui.shown = ui.shown[:0]
+	q := strings.ToLower(ui.query)
+	for i, li := range items {
+		ui.items[i] = li.(item)
+		if q == "" || strings.Contains(strings.ToLower(ui.items[i].FilterValue()), q) {
+			ui.shown = append(ui.shown, ui.items[i])
+		}
+	}
+	ui.page = 0
+}
+
+func (ui *lineUI) pages() int {
+	return max((len(ui.shown)+linePageSize-1)/linePageSize, 1)
+}
+
+func (ui *lineUI) printPage() {
+	if len(ui.shown) == 0 {
+		ui.printf("%s\n", tr("No repositories match %q.", ui.query))
+		return
+	}
+	ui.printf("%s\n", tr("Page %d of %d, sorted by %s:", ui.page+1, ui.pages(), tr(ui.sort.String())))
+	start := ui.page * linePageSize
+	for i := start; i < min(start+linePageSize, len(ui.shown)); i++ {
+		it := ui.shown[i]
+		ui.printf("%d. %s, %s\n", i+1, it.Title(), it.Description())
+	}
+}
+
+func (ui *lineUI) printHelp() {
+	for _, line := range []string{
+		tr("a number: show that repository and its actions"),
+		tr("n, p: next or previous page"),
+		tr("/text: show repositories containing text; / alone shows all"),
+		tr("s: cycle sort (name, stars, updated)"),
+		tr("u name: list another account"),
+		tr("l: list the page again"),
+		tr("q: quit"),
+	} {
+		ui.printf("%s\n", line)
+	}
+}
+
+// command runs one line typed at the list prompt, reporting whether to
+// quit.
+func (ui *lineUI) command(line string) bool {
+	switch {
+	case line == "":
+		return false
+	case line == "q" || line == "quit":
+		return true
+	case line == "h" || line == "?" || line == "help":
+		ui.printHelp()
+	case line == "l":
+		ui.printPage()
+	case line == "n":
+		if ui.page+1 >= ui.pages() {
+			ui.printf("%s\n", tr("This is the last page."))
+			return false
+		}
+		ui.page++
+		ui.printPage()
+	case line == "p":
+		if ui.page == 0 {
+			ui.printf("%s\n", tr("This is the first page."))
+			return false
+		}
+		ui.page--
+		ui.printPage()
+	case line == "s":
+		ui.sort = ui.sort.next()
+		ui.resort()
+		ui.printPage()
+	case strings.HasPrefix(line, "/"):
+		ui.query = strings.TrimSpace(line[1:])
+		ui.resort()
+		ui.printPage()
+	case line == "u" || strings.HasPrefix(line, "u "):
+		user := strings.TrimSpace(strings.TrimPrefix(line, "u"))
+		if user == "" {
+			answer, err := ui.ask(tr("GitHub username: "))
+			if err != nil || answer == "" {
+				return false
+			}
+			user = answer
+		}
+		if err := ui.load(user); err != nil {
+			ui.printf("%s\n", err)
+			return false
+		}
+		ui.printPage()
+	default:
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 1 || n > len(ui.shown) {
+			ui.printf("%s\n", tr("Unknown command %q. Type h for help.", line))
+			return false
+		}
+		return ui.repository(ui.shown[n-1])
+	}
+	return false
+}
+
+// repository describes it and asks what to do with it, reporting whether
+// to quit.
+func (ui *lineUI) repository(it item) bool {
+	ui.printf("%s\n", it.Title())
+	if it.description != "" {
+		ui.printf("%s\n", it.description)
+	}
+	license := it.license
+	if license == "" {
+		license = tr("none")
+	}
+	rows := [][2]string{
+		{tr("size"), humanSize(it.size)},
+		{tr("stars"), strconv.Itoa(it.stars)},
+		{tr("language"), it.language},
+		{tr("license"), license},
+		{tr("topics"), strings.Join(itemTopics(it), ", ")},
+		{tr("clone"), it.url},
+		{tr("note"), it.note},
+	}
+	if !it.updated.IsZero() {
+		rows = append(rows, [2]string{tr("updated"), relativeTime(it.updated)})
+	}
+	for _, row := range rows {
+		if row[1] != "" {
+			ui.printf("%s: %s\n", row[0], row[1])
+		}
+	}
+
+	for {
+		answer, err := ui.ask(tr("c: clone, s: shallow clone, b: back, q: quit? "))
+		if err != nil {
+			return err == io.EOF
+		}
+		switch answer {
+		case "c":
+			ui.clone(it, gitls.CloneOptions{})
+			return false
+		case "s":
+			ui.clone(it, gitls.CloneOptions{Depth: 1})
+			return false
+		case "", "b":
+			return false
+		case "q":
+			return true
+		}
+	}
+}
+
+// clone clones it like enter in the TUI, asking before large or LFS
+// clones and when the destination is taken.
+func (ui *lineUI) clone(it item, opts gitls.CloneOptions) {
+	ui.printf("%s\n", tr("Checking %s for Git LFS...", it.name))
+	checked := checkLFS(it)().(lfsCheckedMsg)
+	it.lfs = checked.lfs
+	if opts.Depth == 0 && (it.lfs || (cfg.LargeRepoMB > 0 && it.size > cfg.LargeRepoMB*1024)) {
+		if it.lfs {
+			ui.printf("%s\n", tr("%s uses Git LFS; downloading LFS objects counts against the owner's bandwidth quota.", it.name))
+		} else {
+			ui.printf("%s\n", tr("%s is %s.", it.name, humanSize(it.size)))
+		}
+		answer, _ := ui.ask(tr("y: clone, s: shallow clone, n: cancel? "))
+		switch answer {
+		case "y":
+		case "s":
+			opts.Depth = 1
+		default:
+			ui.printf("%s\n", tr("Clone cancelled"))
+			return
+		}
+	}
+
+	dest := cloneTarget(it)
+	replace := false
+	reason, cloned := checkDest(it, dest)
+	switch {
+	case cloned:
+		ui.printf("%s\n", tr("%s is already cloned in %s/", it.name, dest))
+		return
+	case reason != "":
+		ui.printf("%s/ %s.\n", dest, reason)
+		alt := freeDest(dest)
+		answer, _ := ui.ask(tr("r: clone into %s/, o: overwrite (moves it to the trash), n: cancel? ", alt))
+		switch answer {
+		case "r":
+			dest = alt
+		case "o":
+			replace = true
+		default:
+			ui.printf("%s\n", tr("Clone cancelled"))
+			return
+		}
+	}
+
+	ui.printf("%s\n", tr("Cloning %s...", it.name))
+	msg := cloneRepo(it, dest, replace, opts)().(cloneFinishedMsg)
+	recordClone(msg.repo, msg.url, msg.dir, msg.err)
+	switch {
+	case msg.err != nil:
+		ui.printf("%s\n", tr("Error cloning: %s", gitFailure(msg.err, msg.url, msg.target)))
+	case msg.hookErr != nil:
+		ui.printf("%s\n", tr("Cloned to %s/, but %v", msg.dir, msg.hookErr))
+	default:
+		ui.printf("%s\n", tr("Cloned to %s/", msg.dir))
+	}
+	if msg.err == nil {
+		recordSessionClone(msg.repo, msg.url, msg.dir, msg.opts, nil)
+	}
+}
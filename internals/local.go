@@ -0,0 +1,332 @@
+package internals
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	dirtyStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	cleanStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	aheadStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	behindStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+)
+
+type localItem struct {
+	path        string
+	name        string
+	remote      string
+	branch      string
+	dirty       bool
+	hasUpstream bool
+	ahead       int
+	behind      int
+	selected    bool
+	pullResult  string
+}
+
+func (i localItem) Title() string {
+	mark := "  "
+	if i.selected {
+		mark = glyph("✔ ", "* ")
+	}
+	title := mark + i.name + " " + cleanStyle.Render(glyph("✓ ", "")+tr("clean"))
+	if i.dirty {
+		title = mark + i.name + " " + dirtyStyle.Render(glyph("● ", "")+tr("dirty"))
+	}
+	if i.ahead > 0 {
+		title += " " + aheadStyle.Render(fmt.Sprintf("%s%d", glyph("↑", "ahead "), i.ahead))
+	}
+	if i.behind > 0 {
+		title += " " + behindStyle.Render(fmt.Sprintf("%s%d", glyph("↓", "behind "), i.behind))
+	}
+	return title
+}
+
+func (i localItem) Description() string {
+	remote := i.remote
+	if remote == "" {
+		remote = tr("no remote")
+	}
+	desc := i.branch + " · " + remote
+	if !i.hasUpstream {
+		desc = i.branch + " " + tr("(no upstream)") + " · " + remote
+	}
+	if i.pullResult != "" {
+		desc += " · " + tr("pull: %s", pullResultLabel(i.pullResult))
+	}
+	return desc
+}
+
+func (i localItem) FilterValue() string { return i.name }
+
+func findGitRepos(root string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// unreadable directories shouldn't abort the whole scan
+			return fs.SkipDir
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if d.Name() == ".git" || d.Name() == "node_modules" {
+			return fs.SkipDir
+		}
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			paths = append(paths, path)
+			return fs.SkipDir
+		}
+		return nil
+	})
+	return paths, err
+}
+
+func inspectLocalRepo(root, path string) localItem {
+	name, err := filepath.Rel(root, path)
+	if err != nil || name == "." {
+		name = filepath.Base(path)
+	}
+
+	it := localItem{path: path, name: name}
+	if out, err := runGit("-C", path, "remote", "get-url", "origin"); err == nil {
+		it.remote = strings.TrimSpace(string(out))
+	}
+	if out, err := runGit("-C", path, "rev-parse", "--abbrev-ref", "HEAD"); err == nil {
+		it.branch = strings.TrimSpace(string(out))
+	}
+	if out, err := runGit("-C", path, "status", "--porcelain"); err == nil {
+		it.dirty = len(strings.TrimSpace(string(out))) > 0
+	}
+	if out, err := runGit("-C", path, "rev-list", "--left-right", "--count", "HEAD...@{upstream}"); err == nil {
+		it.hasUpstream = true
+		fmt.Sscanf(string(out), "%d %d", &it.ahead, &it.behind)
+	}
+	return it
+}
+
+func scanLocalRepos(root string) ([]localItem, error) {
+	paths, err := findGitRepos(root)
+	if err != nil {
+		return nil, err
+	}
+	return inspectLocalRepos(root, paths, false), nil
+}
+
+func inspectLocalRepos(root string, paths []string, fetch bool) []localItem {
+	items := make([]localItem, len(paths))
+	sem := make(chan struct{}, 8)
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if fetch {
+				// a failed fetch still leaves the last known counts useful
+				runGit("-C", path, "fetch", "--quiet")
+			}
+			items[i] = inspectLocalRepo(root, path)
+		}()
+	}
+	wg.Wait()
+	return items
+}
+
+type localFetchedMsg struct {
+	items []localItem
+}
+
+func fetchLocalRepos(root string, items []list.Item) tea.Cmd {
+	paths := make([]string, len(items))
+	for i, li := range items {
+		paths[i] = li.(localItem).path
+	}
+	return func() tea.Msg {
+		return localFetchedMsg{items: inspectLocalRepos(root, paths, true)}
+	}
+}
+
+type localModel struct {
+	root     string
+	list     list.Model
+	width    int
+	height   int
+	fetching bool
+	pulling  bool
+
+	addingWorktree bool
+	worktreeInput  textinput.Model
+	message        string // result of the last worktree action
+	messageErr     bool
+
+	lastClick clickState
+}
+
+func newLocalModel(root string, repos []localItem) localModel {
+	items := make([]list.Item, len(repos))
+	for i, repo := range repos {
+		items[i] = repo
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	localizeList(&l)
+	// f fetches, so it no longer pages
+	l.KeyMap.NextPage = key.NewBinding(key.WithKeys("right", "l", "pgdown", "d"), key.WithHelp("→/l/pgdn", tr("next page")))
+	l.Title = tr("Local repositories in %s", root)
+	l.SetSize(80, 24)
+
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("f"), key.WithHelp("f", tr("fetch"))),
+			key.NewBinding(key.WithKeys(" "), key.WithHelp("space", tr("select"))),
+			key.NewBinding(key.WithKeys("P"), key.WithHelp("P", tr("pull selected"))),
+			key.NewBinding(key.WithKeys("w"), key.WithHelp("w", tr("add worktree"))),
+		}
+	}
+
+	return localModel{root: root, list: l, fetching: true}
+}
+
+func (m localModel) Init() tea.Cmd {
+	return fetchLocalRepos(m.root, m.list.Items())
+}
+
+func (m localModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.addingWorktree {
+			return m.updateWorktree(msg)
+		}
+		if m.list.FilterState() != list.Filtering {
+			switch msg.String() {
+			case "f":
+				if !m.fetching && !m.pulling {
+					m.fetching = true
+					return m, fetchLocalRepos(m.root, m.list.Items())
+				}
+				return m, nil
+			case " ":
+				if it, ok := m.list.SelectedItem().(localItem); ok {
+					it.selected = !it.selected
+					return m, m.list.SetItem(m.list.GlobalIndex(), it)
+				}
+				return m, nil
+			case "P":
+				if !m.fetching && !m.pulling {
+					return m.startPull()
+				}
+				return m, nil
+			case "w":
+				return m.startWorktree()
+			}
+		}
+	case tea.MouseMsg:
+		handleListMouse(&m.list, &m.lastClick, msg)
+		return m, nil
+	case localFetchedMsg:
+		m.fetching = false
+		return m, m.replaceItems(msg.items)
+	case localPulledMsg:
+		m.pulling = false
+		return m, m.replaceItems(msg.items)
+	case worktreeAddedMsg:
+		if msg.err != nil {
+			m.message, m.messageErr = tr("Error adding worktree: %v", msg.err), true
+			return m, nil
+		}
+		m.message, m.messageErr = tr("Added worktree %s", msg.dest), false
+		return m, m.replaceItems(msg.items)
+	case tea.WindowSizeMsg:
+		h, v := normalStyle.GetFrameSize()
+		m.width = msg.Width
+		m.height = msg.Height
+		m.list.SetSize(msg.Width-h, msg.Height-v-1)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m localModel) statusBar() string {
+	var dirty, needPush, needPull int
+	for _, li := range m.list.Items() {
+		it := li.(localItem)
+		if it.dirty {
+			dirty++
+		}
+		if it.ahead > 0 {
+			needPush++
+		}
+		if it.behind > 0 {
+			needPull++
+		}
+	}
+
+	parts := []string{
+		"local",
+		m.root,
+		tr("%d repos", len(m.list.Items())),
+		tr("%d dirty", dirty),
+		tr("%d to push", needPush),
+		tr("%d to pull", needPull),
+	}
+	if m.fetching {
+		parts = append(parts, tr("fetching..."))
+	}
+	if m.pulling {
+		parts = append(parts, tr("pulling..."))
+	} else if summary := pullSummary(m.localItems()); summary != "" {
+		parts = append(parts, summary)
+	}
+	if f := m.list.FilterValue(); f != "" {
+		parts = append(parts, tr("filter: %s", f))
+	}
+	return statusStyle.Width(m.width).Render(strings.Join(parts, " · "))
+}
+
+func (m localModel) View() string {
+	body := m.list.View()
+	if m.addingWorktree {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, m.worktreeInput.View())
+	} else if m.message != "" {
+		style := successStyle
+		if m.messageErr {
+			style = errorStyle
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, body, style.Render(m.message))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, normalStyle.Render(body), m.statusBar())
+}
+
+// LocalRun scans dir for git repositories and shows them in the list UI.
+func LocalRun(dir string) error {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	repos, err := scanLocalRepos(root)
+	if err != nil {
+		return err
+	}
+
+	p := tea.NewProgram(newLocalModel(root, repos), tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err = p.Run()
+	return err
+}
@@ -0,0 +1,132 @@
+package internals
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type localPulledMsg struct {
+	items []localItem
+}
+
+// pullLocalRepo pulls it and returns "updated", "up to date" or "failed: "
+// and git's message, which pullResultLabel translates for display.
+func pullLocalRepo(it localItem) string {
+	before, _ := runGit("-C", it.path, "rev-parse", "HEAD")
+	if _, err := runGit("-C", it.path, "pull", "--ff-only"); err != nil {
+		// keep only the first line of git's output, the list row is narrow
+		msg := strings.TrimSpace(err.Error())
+		if i := strings.IndexByte(msg, '\n'); i >= 0 {
+			msg = msg[:i]
+		}
+		return "failed: " + msg
+	}
+	after, _ := runGit("-C", it.path, "rev-parse", "HEAD")
+	if string(before) == string(after) {
+		return "up to date"
+	}
+	return "updated"
+}
+
+func pullLocalRepos(root string, all []localItem, targets map[string]bool) tea.Cmd {
+	return func() tea.Msg {
+		items := make([]localItem, len(all))
+		sem := make(chan struct{}, 8)
+		var wg sync.WaitGroup
+		for i, it := range all {
+			if !targets[it.path] {
+				items[i] = it
+				continue
+			}
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				result := pullLocalRepo(it)
+				items[i] = inspectLocalRepo(root, it.path)
+				items[i].pullResult = result
+				items[i].selected = it.selected
+			}()
+		}
+		wg.Wait()
+		return localPulledMsg{items: items}
+	}
+}
+
+func (m localModel) localItems() []localItem {
+	items := make([]localItem, len(m.list.Items()))
+	for i, li := range m.list.Items() {
+		items[i] = li.(localItem)
+	}
+	return items
+}
+
+// startPull pulls every selected repository, or the one under the cursor
+// when nothing is selected.
+func (m localModel) startPull() (tea.Model, tea.Cmd) {
+	targets := make(map[string]bool)
+	for _, it := range m.localItems() {
+		if it.selected {
+			targets[it.path] = true
+		}
+	}
+	if len(targets) == 0 {
+		it, ok := m.list.SelectedItem().(localItem)
+		if !ok {
+			return m, nil
+		}
+		targets[it.path] = true
+	}
+
+	m.pulling = true
+	return m, pullLocalRepos(m.root, m.localItems(), targets)
+}
+
+// replaceItems swaps in freshly inspected repos, keeping the selection.
+func (m *localModel) replaceItems(fresh []localItem) tea.Cmd {
+	selected := make(map[string]bool)
+	results := make(map[string]string)
+	for _, it := range m.localItems() {
+		selected[it.path] = it.selected
+		results[it.path] = it.pullResult
+	}
+
+	items := make([]list.Item, len(fresh))
+	for i, it := range fresh {
+		it.selected = selected[it.path]
+		if it.pullResult == "" {
+			it.pullResult = results[it.path]
+		}
+		items[i] = it
+	}
+	return m.list.SetItems(items)
+}
+
+func pullSummary(items []localItem) string {
+	var updated, upToDate, failed int
+	for _, it := range items {
+		switch {
+		case it.pullResult == "updated":
+			updated++
+		case it.pullResult == "up to date":
+			upToDate++
+		case strings.HasPrefix(it.pullResult, "failed"):
+			failed++
+		}
+	}
+	if updated+upToDate+failed == 0 {
+		return ""
+	}
+	return tr("pulled %d, up to date %d, failed %d", updated, upToDate, failed)
+}
+
+func pullResultLabel(result string) string {
+	if msg, ok := strings.CutPrefix(result, "failed: "); ok {
+		return tr("failed: %s", msg)
+	}
+	return tr(result)
+}
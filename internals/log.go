@@ -0,0 +1,61 @@
+package internals
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var logger = slog.New(slog.DiscardHandler)
+
+func stateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "gitls"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "gitls"), nil
+}
+
+// EnableDebug routes structured debug logs to a file in the XDG state dir
+// and returns the path so it can be mentioned in bug reports.
+func EnableDebug() (string, io.Closer, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", nil, err
+	}
+
+	path := filepath.Join(dir, "debug.log")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return "", nil, err
+	}
+
+	logger = slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	logger.Info("debug logging enabled", "pid", os.Getpid())
+	return path, f, nil
+}
+
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		logger.Debug("api request failed", "method", req.Method, "url", req.URL.String(), "took", time.Since(start), "err", err)
+		return nil, err
+	}
+	logger.Debug("api request", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "took", time.Since(start))
+	noteRateHeaders(resp.Header)
+	return resp, nil
+}
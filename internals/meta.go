@@ -0,0 +1,19 @@
+package internals
+
+import (
+	"sync"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+)
+
+// metaCache keeps what GraphQL listings found about repositories, keyed by
+// full name, for the detail pane.
+var metaCache sync.Map
+
+func metaFor(fullName string) (gitls.RepoMeta, bool) {
+	v, ok := metaCache.Load(fullName)
+	if !ok {
+		return gitls.RepoMeta{}, false
+	}
+	return v.(gitls.RepoMeta), true
+}
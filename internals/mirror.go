@@ -0,0 +1,207 @@
+package internals
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// MirrorManifestName is the manifest file mirror init writes into the
+// mirror directory.
+const MirrorManifestName = "gitls-mirror.json"
+
+const mirrorManifestVersion = 1
+
+// mirrorManifest describes a set of bare mirrors of one account, so a
+// scheduled mirror run can keep them up to date without any flags.
+type mirrorManifest struct {
+	Version     int          `json:"version"`
+	Account     string       `json:"account"`
+	Profile     string       `json:"profile,omitempty"`
+	Dir         string       `json:"dir"`
+	Layout      string       `json:"layout"`
+	Include     []string     `json:"include,omitempty"`
+	Exclude     []string     `json:"exclude,omitempty"`
+	Concurrency int          `json:"concurrency"`
+	TrackNew    bool         `json:"track_new"` // add repositories created after init on each run
+	Created     time.Time    `json:"created"`
+	Repos       []mirrorRepo `json:"repos"`
+}
+
+type mirrorRepo struct {
+	Name string `json:"name"` // owner/name
+	URL  string `json:"url"`
+	Path string `json:"path"`
+}
+
+// MirrorOptions are the settings mirror init records.
+type MirrorOptions struct {
+	Dir         string
+	Layout      string
+	Include     []string
+	Exclude     []string
+	Concurrency int
+	TrackNew    bool
+	Force       bool // overwrite an existing manifest
+}
+
+func (m *mirrorManifest) batchOptions() BatchOptions {
+	return BatchOptions{
+		All:         true,
+		Mirror:      true,
+		Include:     m.Include,
+		Exclude:     m.Exclude,
+		Concurrency: m.Concurrency,
+		Dir:         m.Dir,
+		Layout:      m.Layout,
+	}
+}
+
+func (m *mirrorManifest) add(repo *github.Repository) {
+	m.Repos = append(m.Repos, mirrorRepo{
+		Name: repo.GetFullName(),
+		URL:  cloneURL(repo),
+		Path: cloneDest(repo, m.batchOptions()),
+	})
+}
+
+func (m *mirrorManifest) has(fullName string) bool {
+	for _, r := range m.Repos {
+		if r.Name == fullName {
+			return true
+		}
+	}
+	return false
+}
+
+func writeManifest(path string, m *mirrorManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func readManifest(path string) (*mirrorManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m mirrorManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if m.Version != mirrorManifestVersion {
+		return nil, fmt.Errorf("%s: unsupported manifest version %d", path, m.Version)
+	}
+	return &m, nil
+}
+
+// MirrorInit lists account and writes a manifest of bare mirrors into
+// opts.Dir without cloning anything.
+func MirrorInit(account string, opts MirrorOptions) error {
+	dir, err := filepath.Abs(opts.Dir)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, MirrorManifestName)
+	if _, err := os.Stat(path); err == nil && !opts.Force {
+		return fmt.Errorf("%s already exists (use --force to replace it)", path)
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	m := &mirrorManifest{
+		Version:     mirrorManifestVersion,
+		Account:     account,
+		Profile:     activeProfileName,
+		Dir:         dir,
+		Layout:      opts.Layout,
+		Include:     opts.Include,
+		Exclude:     opts.Exclude,
+		Concurrency: max(opts.Concurrency, 1),
+		TrackNew:    opts.TrackNew,
+		Created:     time.Now().UTC().Truncate(time.Second),
+	}
+	repos, _, err := fetchRepos(account)
+	if err != nil {
+		return err
+	}
+	for _, repo := range selectRepos(repos, m.batchOptions()) {
+		m.add(repo)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := writeManifest(path, m); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s with %d repositories of %s\n", path, len(m.Repos), account)
+	fmt.Printf("run it with: gitls mirror run %s\n", path)
+	return nil
+}
+
+// MirrorRun brings the mirrors in the manifest at path up to date: missing
+// ones are cloned and existing ones fetched, so running it again changes
+// nothing. With track_new, repositories created since the last run are
+// added to the manifest first.
+func MirrorRun(path string) error {
+	m, err := readManifest(path)
+	if err != nil {
+		return err
+	}
+	if m.Profile != "" && m.Profile != activeProfileName {
+		if err := UseProfile(m.Profile); err != nil {
+			return err
+		}
+	}
+
+	if m.TrackNew {
+		repos, _, err := fetchRepos(m.Account)
+		if err != nil {
+			return err
+		}
+		added := 0
+		for _, repo := range selectRepos(repos, m.batchOptions()) {
+			if !m.has(repo.GetFullName()) {
+				m.add(repo)
+				added++
+			}
+		}
+		if added > 0 {
+			if err := writeManifest(path, m); err != nil {
+				return err
+			}
+			fmt.Printf("%d new repositories added to %s\n", added, path)
+		}
+	}
+
+	repos := make([]*github.Repository, len(m.Repos))
+	paths := make(map[string]string, len(m.Repos))
+	for i, r := range m.Repos {
+		owner, name, _ := strings.Cut(r.Name, "/")
+		repos[i] = &github.Repository{
+			Name:     github.String(name),
+			FullName: github.String(r.Name),
+			CloneURL: github.String(r.URL),
+			Owner:    &github.User{Login: github.String(owner)},
+		}
+		paths[r.Name] = r.Path
+	}
+	fmt.Printf("%d mirrors of %s in %s\n", len(repos), m.Account, m.Dir)
+	return runRepos(m.Account, repos, m.batchOptions(), func(repo *github.Repository, opts BatchOptions) batchResult {
+		return syncOneTo(repo, paths[repo.GetFullName()], opts)
+	})
+}
@@ -0,0 +1,65 @@
+package internals
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const doubleClickInterval = 400 * time.Millisecond
+
+type clickState struct {
+	index int
+	at    time.Time
+}
+
+// listIndexAt maps a terminal row to the index of the visible list item
+// rendered there. It assumes the default delegate and that the list is
+// drawn inside normalStyle.
+func listIndexAt(l list.Model, y int) (int, bool) {
+	top := normalStyle.GetMarginTop()
+	if l.ShowTitle() || l.ShowFilter() {
+		top += 1 + l.Styles.TitleBar.GetVerticalFrameSize()
+	}
+	if l.ShowStatusBar() {
+		top += 1 + l.Styles.StatusBar.GetVerticalFrameSize()
+	}
+
+	d := list.NewDefaultDelegate()
+	row := y - top
+	if row < 0 || row%(d.Height()+d.Spacing()) >= d.Height() {
+		return 0, false
+	}
+
+	index := l.Paginator.Page*l.Paginator.PerPage + row/(d.Height()+d.Spacing())
+	if index >= len(l.VisibleItems()) {
+		return 0, false
+	}
+	return index, true
+}
+
+// handleListMouse scrolls l with the wheel and selects clicked rows. It
+// reports whether the click was the second one on the same row.
+func handleListMouse(l *list.Model, last *clickState, msg tea.MouseMsg) bool {
+	if msg.Action != tea.MouseActionPress {
+		return false
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		l.CursorUp()
+	case tea.MouseButtonWheelDown:
+		l.CursorDown()
+	case tea.MouseButtonLeft:
+		index, ok := listIndexAt(*l, msg.Y)
+		if !ok {
+			return false
+		}
+		l.Select(index)
+		double := last.index == index && time.Since(last.at) < doubleClickInterval
+		*last = clickState{index: index, at: time.Now()}
+		return double
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package internals
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// navPushMsg opens a screen on top of the current one.
+type navPushMsg struct{ model tea.Model }
+
+// navBackMsg returns to the screen below, or quits from the first one.
+// then, if set, is handed to the screen below.
+type navBackMsg struct{ then tea.Msg }
+
+// navigate opens model as a new page; going back returns to the screen
+// that was showing.
+func navigate(model tea.Model) tea.Cmd {
+	return func() tea.Msg { return navPushMsg{model: model} }
+}
+
+// pageLoadedMsg carries a page that took a request to build, for the
+// screen that asked for it to open.
+type pageLoadedMsg struct {
+	model tea.Model
+	err   error
+}
+
+// goBack is the command for leaving the current page.
+func goBack() tea.Msg {
+	return navBackMsg{}
+}
+
+// backWith leaves the current page and hands msg to the one below, e.g. to
+// act on what was picked.
+func backWith(msg tea.Msg) tea.Cmd {
+	return func() tea.Msg { return navBackMsg{then: msg} }
+}
+
+// crumbed screens name themselves in the breadcrumb header. Screens opened
+// from a list include the list's crumbs.
+type crumbed interface {
+	crumbs() []string
+}
+
+// closer is a page that has to stop work of its own, like a running
+// command, when it is left.
+type closer interface {
+	close()
+}
+
+func crumbsOf(model tea.Model) []string {
+	if c, ok := model.(crumbed); ok {
+		return c.crumbs()
+	}
+	return nil
+}
+
+// navHeaderHeight is the breadcrumb line above every screen.
+const navHeaderHeight = 1
+
+// termSize is the space below the header. A new screen starts with it,
+// since bubbletea only sends a WindowSizeMsg when the terminal changes.
+var termSize tea.WindowSizeMsg
+
+// navModel is the root of the TUI. It keeps the pages opened with navigate
+// so going back (esc, or alt+left from anywhere) returns to the previous
+// one, and draws the breadcrumb header.
+type navModel struct {
+	stack []tea.Model
+	size  tea.WindowSizeMsg
+}
+
+func newNavModel(root tea.Model) navModel {
+	return navModel{stack: []tea.Model{root}}
+}
+
+func (m navModel) top() tea.Model {
+	return m.stack[len(m.stack)-1]
+}
+
+// forward hands msg to the current screen, which may replace itself.
+func (m navModel) forward(msg tea.Msg) (navModel, tea.Cmd) {
+	next, cmd := m.top().Update(msg)
+	m.stack[len(m.stack)-1] = next
+	return m, cmd
+}
+
+// resizeTop tells the current screen the space it has below the header.
+func (m navModel) resizeTop() (navModel, tea.Cmd) {
+	if m.size.Width == 0 {
+		return m, nil
+	}
+	termSize = tea.WindowSizeMsg{Width: m.size.Width, Height: m.size.Height - navHeaderHeight}
+	return m.forward(termSize)
+}
+
+func (m navModel) Init() tea.Cmd {
+	return m.top().Init()
+}
+
+func (m navModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.size = msg
+		return m.resizeTop()
+	case tea.MouseMsg:
+		msg.Y -= navHeaderHeight
+		return m.forward(msg)
+	case navPushMsg:
+		// copy so the pages below aren't shared with older navModel values
+		m.stack = append(m.stack[:len(m.stack):len(m.stack)], msg.model)
+		init := msg.model.Init()
+		m, cmd := m.resizeTop()
+		return m, tea.Batch(init, cmd)
+	case navBackMsg:
+		return m.back(msg.then)
+	case tea.KeyMsg:
+		if msg.String() == "alt+left" {
+			return m.back(nil)
+		}
+	}
+	return m.forward(msg)
+}
+
+func (m navModel) back(then tea.Msg) (tea.Model, tea.Cmd) {
+	if len(m.stack) == 1 {
+		return m, tea.Quit
+	}
+	if c, ok := m.top().(closer); ok {
+		c.close()
+	}
+	m.stack = m.stack[:len(m.stack)-1]
+	m, cmd := m.resizeTop()
+	if then != nil {
+		var thenCmd tea.Cmd
+		m, thenCmd = m.forward(then)
+		cmd = tea.Batch(cmd, thenCmd)
+	}
+	if rm, ok := m.top().(repoModel); ok {
+		cmd = tea.Batch(cmd, rm.setWindowTitle())
+	}
+	return m, cmd
+}
+
+func (m navModel) header() string {
+	crumbs := []string{"gitls"}
+	for _, page := range m.stack {
+		crumbs = append(crumbs, crumbsOf(page)...)
+	}
+	sep := " " + glyph("›", ">") + " "
+	line := strings.Join(crumbs, sep)
+	if m.size.Width > 0 && len([]rune(line)) > m.size.Width-normalStyle.GetMarginLeft() {
+		// keep the end of the trail, which is where you are
+		for len(crumbs) > 2 && len([]rune(strings.Join(crumbs, sep))) > m.size.Width-normalStyle.GetMarginLeft() {
+			crumbs = append([]string{"…"}, crumbs[2:]...)
+		}
+		line = strings.Join(crumbs, sep)
+	}
+	return strings.Repeat(" ", normalStyle.GetMarginLeft()) + badgeStyle.Render(line)
+}
+
+func (m navModel) View() string {
+	return m.header() + "\n" + m.top().View()
+}
@@ -0,0 +1,80 @@
+package internals
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// notes maps owner/name to a short free-form note, loaded on first use.
+var notes map[string]string
+
+func loadNotes() map[string]string {
+	if notes != nil {
+		return notes
+	}
+	notes = make(map[string]string)
+	if err := readStateFile("notes.json", &notes); err != nil {
+		logger.Warn("could not read notes", "err", err)
+	}
+	return notes
+}
+
+func noteFor(it item) string {
+	return loadNotes()[it.owner+"/"+it.name]
+}
+
+func setNote(it item, note string) error {
+	loadNotes()
+	if note == "" {
+		delete(notes, it.owner+"/"+it.name)
+	} else {
+		notes[it.owner+"/"+it.name] = note
+	}
+	return writeStateFile("notes.json", notes)
+}
+
+func newNoteInput(note string) textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = tr("note: ")
+	ti.CharLimit = 200
+	ti.SetValue(note)
+	ti.Focus()
+	return ti
+}
+
+func (m repoModel) editNote() (tea.Model, tea.Cmd) {
+	it, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return m, nil
+	}
+	m.noteInput = newNoteInput(it.note)
+	m.editingNote = true
+	return m, textinput.Blink
+}
+
+func (m repoModel) updateNote(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.editingNote = false
+		return m, nil
+	case tea.KeyEnter:
+		m.editingNote = false
+		it, ok := m.list.SelectedItem().(item)
+		if !ok {
+			return m, nil
+		}
+		it.note = strings.TrimSpace(m.noteInput.Value())
+		if err := setNote(it, it.note); err != nil {
+			m.cloneError = true
+			m.cloneMsg = tr("Error saving note: %v", err)
+			return m, nil
+		}
+		return m, m.list.SetItem(m.list.GlobalIndex(), it)
+	}
+
+	var cmd tea.Cmd
+	m.noteInput, cmd = m.noteInput.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,216 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v50/github"
+)
+
+type notification struct {
+	id      string
+	title   string
+	kind    string // Issue, PullRequest, CheckSuite, ...
+	reason  string
+	repo    string // owner/name
+	updated time.Time
+	unread  bool
+}
+
+func (n notification) Title() string {
+	if n.unread {
+		return glyph("● ", "* ") + n.title
+	}
+	return badgeStyle.Render(n.title)
+}
+
+func (n notification) Description() string {
+	parts := []string{n.repo}
+	if n.kind != "" {
+		parts = append(parts, n.kind)
+	}
+	parts = append(parts, reasonLabel(n.reason), relativeTime(n.updated))
+	return strings.Join(parts, " · ")
+}
+
+func (n notification) FilterValue() string { return n.repo + " " + n.title }
+
+// reasonLabel names the common notification reasons the way the web inbox
+// does.
+func reasonLabel(reason string) string {
+	switch reason {
+	case "mention", "team_mention":
+		return "mentioned"
+	case "review_requested":
+		return "review requested"
+	case "ci_activity":
+		return "CI"
+	case "assign":
+		return "assigned"
+	case "author":
+		return "author"
+	case "comment":
+		return "commented"
+	case "state_change":
+		return "state changed"
+	default:
+		return strings.ReplaceAll(reason, "_", " ")
+	}
+}
+
+type notificationsFetchedMsg struct {
+	items []list.Item
+	err   error
+}
+
+func fetchNotifications() tea.Cmd {
+	return func() tea.Msg {
+		if activeProfile.provider() != providerGitHub {
+			return notificationsFetchedMsg{err: fmt.Errorf("notifications are only supported for GitHub profiles")}
+		}
+		opts := &github.NotificationListOptions{ListOptions: github.ListOptions{PerPage: 50}}
+		found, _, err := newAPI().ListNotifications(context.Background(), opts)
+		if err != nil {
+			return notificationsFetchedMsg{err: fmt.Errorf("failed to fetch notifications: %w", err)}
+		}
+		items := make([]list.Item, len(found))
+		for i, n := range found {
+			items[i] = notification{
+				id:      n.GetID(),
+				title:   n.GetSubject().GetTitle(),
+				kind:    n.GetSubject().GetType(),
+				reason:  n.GetReason(),
+				repo:    n.GetRepository().GetFullName(),
+				updated: n.GetUpdatedAt().Time,
+				unread:  n.GetUnread(),
+			}
+		}
+		return notificationsFetchedMsg{items: items}
+	}
+}
+
+type threadReadMsg struct {
+	id  string
+	err error
+}
+
+func markThreadRead(id string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := newAPI().MarkThreadRead(context.Background(), id)
+		return threadReadMsg{id: id, err: err}
+	}
+}
+
+// notificationsModel is a small inbox of the authenticated user's unread
+// notifications.
+type notificationsModel struct {
+	listUser string // account of the list below
+	list     list.Model
+	loading  bool
+	opening  string // repository being looked up after enter
+	err      error
+}
+
+func newNotificationsModel(listUser string) notificationsModel {
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	localizeList(&l)
+	l.Title = tr("Notifications")
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", tr("jump to repository"))),
+			key.NewBinding(key.WithKeys("r"), key.WithHelp("r", tr("mark as read"))),
+		}
+	}
+	return notificationsModel{listUser: listUser, list: l, loading: true}
+}
+
+func (m notificationsModel) crumbs() []string { return []string{"notifications"} }
+
+func (m notificationsModel) Init() tea.Cmd {
+	return fetchNotifications()
+}
+
+func (m notificationsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case notificationsFetchedMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, m.list.SetItems(msg.items)
+	case pageLoadedMsg:
+		m.opening = ""
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m, navigate(msg.model)
+	case threadReadMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("failed to mark as read: %w", msg.err)
+			return m, nil
+		}
+		for i, li := range m.list.Items() {
+			if n, ok := li.(notification); ok && n.id == msg.id {
+				n.unread = false
+				return m, m.list.SetItem(i, n)
+			}
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			if m.list.FilterState() == list.Unfiltered {
+				return m, goBack
+			}
+		case "r":
+			n, ok := m.list.SelectedItem().(notification)
+			if !ok || !n.unread {
+				return m, nil
+			}
+			return m, markThreadRead(n.id)
+		case "enter":
+			n, ok := m.list.SelectedItem().(notification)
+			if !ok || m.opening != "" {
+				return m, nil
+			}
+			cmd, back := jumpToRepo(m.listUser, n.repo)
+			if !back {
+				m.opening, m.err = n.repo, nil
+			}
+			return m, cmd
+		}
+	case tea.WindowSizeMsg:
+		h, v := normalStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v-1)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m notificationsModel) View() string {
+	body := m.list.View()
+	switch {
+	case m.loading:
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("Loading notifications..."))
+	case m.err == nil && len(m.list.Items()) == 0:
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("No unread notifications."))
+	case m.opening != "":
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("Loading %s...", m.opening))
+	}
+	if m.err != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, errorStyle.Render(m.err.Error()))
+	}
+	return normalStyle.Render(body)
+}
@@ -0,0 +1,61 @@
+package internals
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+)
+
+// notifyAfter is how long a batch has to run before its end is worth a
+// notification.
+const notifyAfter = 30 * time.Second
+
+// windowTitle names the account and selected repository, so a terminal tab
+// running gitls can be found among others.
+func (m repoModel) windowTitle() string {
+	if it, ok := m.list.SelectedItem().(item); ok {
+		return "gitls: " + it.owner + "/" + it.name
+	}
+	if m.username != "" {
+		return "gitls: " + m.username
+	}
+	return "gitls"
+}
+
+func (m repoModel) setWindowTitle() tea.Cmd {
+	return tea.SetWindowTitle(m.windowTitle())
+}
+
+// notify tells the user about something that finished while they were
+// elsewhere, as configured by the notify key: "terminal" writes an OSC 9
+// escape that most terminals turn into a notification, "desktop" uses
+// notify-send or osascript.
+func notify(message string) {
+	switch cfg.Notify {
+	case "terminal":
+		if term.IsTerminal(int(os.Stderr.Fd())) {
+			fmt.Fprintf(os.Stderr, "\x1b]9;%s\x07", strings.ReplaceAll(message, "\x07", ""))
+		}
+	case "desktop":
+		var c *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			script := fmt.Sprintf("display notification %q with title %q", message, "gitls")
+			c = exec.Command("osascript", "-e", script)
+		case "windows":
+			logger.Debug("desktop notifications are not supported on windows")
+			return
+		default:
+			c = exec.Command("notify-send", "gitls", message)
+		}
+		if err := c.Run(); err != nil {
+			logger.Warn("could not send notification", "err", err)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package internals
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorCommand is the configured editor, then $VISUAL, $EDITOR and VS Code.
+func editorCommand() []string {
+	for _, editor := range []string{cfg.Editor, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if fields := strings.Fields(editor); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"code"}
+}
+
+// fileManagerCommand opens a directory with the desktop's default handler.
+func fileManagerCommand(dir string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", dir)
+	case "windows":
+		return exec.Command("explorer", dir)
+	}
+	return exec.Command("xdg-open", dir)
+}
+
+type openFinishedMsg struct {
+	status string
+	err    error
+}
+
+// openInEditor hands the terminal to the editor; graphical editors return
+// straight away.
+func openInEditor(dir string) tea.Cmd {
+	args := editorCommand()
+	c := exec.Command(args[0], append(args[1:], dir)...)
+	c.Dir = dir
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return openFinishedMsg{err: fmt.Errorf("%s: %w", args[0], err)}
+		}
+		return openFinishedMsg{status: tr("Opened %s in %s", dir, args[0])}
+	})
+}
+
+func openInFileManager(dir string) tea.Cmd {
+	return func() tea.Msg {
+		c := fileManagerCommand(dir)
+		if err := c.Start(); err != nil {
+			return openFinishedMsg{err: fmt.Errorf("%s: %w", c.Args[0], err)}
+		}
+		go c.Wait()
+		return openFinishedMsg{status: tr("Opened %s in the file manager", dir)}
+	}
+}
+
+func copyPath(dir string) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(dir); err != nil {
+			return openFinishedMsg{err: fmt.Errorf("failed to copy path: %w", err)}
+		}
+		return openFinishedMsg{status: tr("Copied %s", dir)}
+	}
+}
+
+// cloneActions is the key hint shown after a clone or download to dir.
+func cloneActions(dir string) string {
+	actions := tr("o: editor · O: file manager · y: copy path")
+	if steps := bootstrapSteps(dir); len(steps) > 0 {
+		actions += " · b: " + strings.Join(steps, " && ")
+	}
+	return actions + " · " + tr("u: undo")
+}
+
+// updateOpen handles the quick actions on the last clone: o opens it in an
+// editor, O in the file manager and y copies its path.
+func (m repoModel) updateOpen(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.lastClone == "" || m.cloning {
+		return m, nil, false
+	}
+	switch msg.String() {
+	case "o":
+		return m, openInEditor(m.lastClone), true
+	case "O":
+		return m, openInFileManager(m.lastClone), true
+	case "y":
+		return m, copyPath(m.lastClone), true
+	}
+	return m, nil, false
+}
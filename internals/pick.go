@@ -0,0 +1,69 @@
+package internals
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// pickMode is "url", "name" or "path" when --pick is given: enter then
+// prints that field of the selected repository instead of cloning it.
+var (
+	pickMode string
+	picked   string
+)
+
+// SetPick turns on picker mode. The TUI is drawn on stderr so that stdout
+// only carries the result, e.g. cd "$(gitls --pick=path)".
+func SetPick(mode string) error {
+	switch mode {
+	case "url", "name", "path":
+	default:
+		return fmt.Errorf("unknown pick field %q: use url, name or path", mode)
+	}
+	pickMode = mode
+	lipgloss.SetColorProfile(termenv.NewOutput(os.Stderr).EnvColorProfile())
+	return nil
+}
+
+func pickValue(it item) string {
+	switch pickMode {
+	case "name":
+		return it.owner + "/" + it.name
+	case "path":
+		return cloneTarget(it)
+	}
+	return rewriteURL(it.url) // what the caller will clone
+}
+
+func (m repoModel) pick() (tea.Model, tea.Cmd) {
+	it, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return m, nil
+	}
+	picked = pickValue(it)
+	return m, tea.Quit
+}
+
+// pickOptions draws the program on stderr in picker mode.
+func pickOptions() []tea.ProgramOption {
+	if pickMode == "" {
+		return nil
+	}
+	return []tea.ProgramOption{tea.WithOutput(os.Stderr)}
+}
+
+// printPicked writes the picked value to stdout. Leaving without picking
+// exits with status 1, so scripts can tell the two apart.
+func printPicked() {
+	if pickMode == "" {
+		return
+	}
+	if picked == "" {
+		os.Exit(1)
+	}
+	fmt.Println(picked)
+}
@@ -0,0 +1,98 @@
+package internals
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+)
+
+type pin struct {
+	Owner       string `json:"owner"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Stars       int    `json:"stars"`
+}
+
+func (p pin) key() string { return p.Owner + "/" + p.Name }
+
+func (p pin) item() item {
+	return item{
+		name:        p.Name,
+		owner:       p.Owner,
+		url:         p.URL,
+		description: p.Description,
+		language:    p.Language,
+		stars:       p.Stars,
+		pinned:      true,
+		note:        loadNotes()[p.key()],
+	}
+}
+
+func pinOf(it item) pin {
+	return pin{
+		Owner:       it.owner,
+		Name:        it.name,
+		URL:         it.url,
+		Description: it.description,
+		Language:    it.language,
+		Stars:       it.stars,
+	}
+}
+
+// pins is loaded on first use and kept in sync with the pins file.
+var pins []pin
+
+func loadPins() []pin {
+	if pins != nil {
+		return pins
+	}
+	pins = []pin{}
+	if err := readStateFile("pins.json", &pins); err != nil {
+		logger.Warn("could not read pins", "err", err)
+	}
+	return pins
+}
+
+func savePins() error {
+	return writeStateFile("pins.json", pins)
+}
+
+func isPinned(it item) bool {
+	k := pinOf(it).key()
+	for _, p := range loadPins() {
+		if p.key() == k {
+			return true
+		}
+	}
+	return false
+}
+
+// togglePin pins or unpins it and reports the new state.
+func togglePin(it item) (bool, error) {
+	k := pinOf(it).key()
+	loadPins()
+	for i, p := range pins {
+		if p.key() == k {
+			pins = append(pins[:i], pins[i+1:]...)
+			return false, savePins()
+		}
+	}
+	pins = append(pins, pinOf(it))
+	return true, savePins()
+}
+
+func pinnedItems() []list.Item {
+	var items []list.Item
+	for _, p := range loadPins() {
+		items = append(items, p.item())
+	}
+	return items
+}
+
+func favoritesModel() repoModel {
+	items := pinnedItems()
+	sortItems(items, sortByName)
+	m := newRepoModel(tr("Favorites"), items)
+	m.crumb = "favorites"
+	return m
+}
@@ -0,0 +1,38 @@
+package internals
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+var plainMode bool
+
+// SetPlain turns off colors, rounded borders and pictographic glyphs for
+// screen readers and terminals that don't cope with ANSI styling.
+func SetPlain(plain bool) {
+	plainMode = plain
+	if !plain {
+		return
+	}
+	lipgloss.SetColorProfile(termenv.Ascii)
+	detailStyle = detailStyle.Border(lipgloss.ASCIIBorder())
+}
+
+// glyph picks the ASCII alternative of a symbol in plain mode.
+func glyph(fancy, plain string) string {
+	if plainMode {
+		return plain
+	}
+	return fancy
+}
+
+func newSpinner() spinner.Model {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	if plainMode {
+		sp.Spinner = spinner.Line
+	}
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	return sp
+}
@@ -0,0 +1,194 @@
+package internals
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	providerGitHub = "github"
+	providerGitLab = "gitlab"
+)
+
+type profile struct {
+	Provider string `json:"provider"` // github (default) or gitlab
+	Token    string `json:"token"`    // falls back to the stored login, then GITHUB_TOKEN (GH_ENTERPRISE_TOKEN with a base_url) / GITLAB_TOKEN
+	BaseURL  string `json:"base_url"` // API root for GitHub Enterprise or self-hosted GitLab
+	CloneDir string `json:"clone_dir"`
+	User     string `json:"user"` // account to open when switching to this profile
+}
+
+var (
+	activeProfileName string
+	activeProfile     profile
+)
+
+func (p profile) provider() string {
+	if p.Provider == "" {
+		return providerGitHub
+	}
+	return p.Provider
+}
+
+func (p profile) token() string {
+	if p.Token != "" {
+		return p.Token
+	}
+	if token := storedToken(); token != "" {
+		return token
+	}
+	if p.provider() == providerGitLab {
+		return os.Getenv("GITLAB_TOKEN")
+	}
+	if p.BaseURL != "" {
+		// never send a github.com token to another host
+		return os.Getenv("GH_ENTERPRISE_TOKEN")
+	}
+	return os.Getenv("GITHUB_TOKEN")
+}
+
+// UseProfile activates the named profile from the config. An empty name
+// selects the configured default profile, or none at all.
+func UseProfile(name string) error {
+	if name == "" {
+		name = cfg.DefaultProfile
+	}
+	if name == "" {
+		activeProfileName, activeProfile = "", profile{}
+		return nil
+	}
+
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return fmt.Errorf("unknown profile %q (have: %s)", name, strings.Join(profileNames(), ", "))
+	}
+	switch p.provider() {
+	case providerGitHub, providerGitLab:
+	default:
+		return fmt.Errorf("profile %q: unsupported provider %q", name, p.Provider)
+	}
+	if p.BaseURL != "" {
+		if _, err := url.Parse(p.BaseURL); err != nil {
+			return fmt.Errorf("profile %q: invalid base_url: %w", name, err)
+		}
+	}
+
+	activeProfileName, activeProfile = name, p
+	return nil
+}
+
+func profileNames() []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type profileModel struct {
+	user   string
+	names  []string
+	cursor int
+	err    error
+}
+
+func newProfileModel(user string) profileModel {
+	m := profileModel{user: user, names: profileNames()}
+	for i, name := range m.names {
+		if name == activeProfileName {
+			m.cursor = i
+		}
+	}
+	return m
+}
+
+func (m profileModel) crumbs() []string { return []string{"profiles"} }
+
+func (m profileModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m profileModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q":
+		return m, goBack
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.names)-1 {
+			m.cursor++
+		}
+	case "enter":
+		if len(m.names) == 0 {
+			return m, goBack
+		}
+		next, err := switchProfile(m.names[m.cursor], m.user)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		return next, nil
+	}
+	return m, nil
+}
+
+// switchProfile makes name the active profile and opens its account, the
+// profile's user when it names one and user otherwise.
+func switchProfile(name, user string) (tea.Model, error) {
+	if err := UseProfile(name); err != nil {
+		return nil, err
+	}
+	if activeProfile.User != "" {
+		user = activeProfile.User
+	}
+	if user == "" {
+		return prepUsernameModel(""), nil
+	}
+	return initialModel(user), nil
+}
+
+func (m profileModel) View() string {
+	var b strings.Builder
+	b.WriteString(tr("Switch profile") + "\n\n")
+
+	if len(m.names) == 0 {
+		b.WriteString(tr("No profiles configured. Add a \"profiles\" section to the config file.") + "\n")
+	}
+	for i, name := range m.names {
+		p := cfg.Profiles[name]
+		line := fmt.Sprintf("%s (%s", name, p.provider())
+		if p.BaseURL != "" {
+			line += ", " + p.BaseURL
+		}
+		line += ")"
+		if name == activeProfileName {
+			line += " *"
+		}
+		if i == m.cursor {
+			b.WriteString(cursorStyle.Render("> "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	if m.err != nil {
+		b.WriteString("\n" + errorStyle.Render(m.err.Error()) + "\n")
+	}
+	b.WriteString("\n" + tr("enter: switch · esc: back"))
+	return normalStyle.Render(b.String())
+}
@@ -0,0 +1,173 @@
+package internals
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v50/github"
+)
+
+// coreRate is the last core rate limit GitHub reported. Anonymous clients
+// get 60 requests an hour, so running out is routine without a token.
+var (
+	rateMu   sync.Mutex
+	coreRate github.Rate
+)
+
+func noteRate(r github.Rate) {
+	if r.Limit == 0 {
+		return
+	}
+	rateMu.Lock()
+	coreRate = r
+	rateMu.Unlock()
+}
+
+// noteRateHeaders records the limit from a REST response. Search and
+// GraphQL have limits of their own and are ignored.
+func noteRateHeaders(h http.Header) {
+	if res := h.Get("X-RateLimit-Resource"); res != "" && res != "core" {
+		return
+	}
+	limit, err1 := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	remaining, err2 := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	reset, err3 := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return
+	}
+	noteRate(github.Rate{Limit: limit, Remaining: remaining, Reset: github.Timestamp{Time: time.Unix(reset, 0)}})
+}
+
+// rateLimited reports when the core limit resets if it is used up.
+func rateLimited() (time.Time, bool) {
+	rateMu.Lock()
+	r := coreRate
+	rateMu.Unlock()
+	if r.Limit == 0 || r.Remaining > 0 || !time.Now().Before(r.Reset.Time) {
+		return time.Time{}, false
+	}
+	return r.Reset.Time, true
+}
+
+func currentRate() github.Rate {
+	rateMu.Lock()
+	defer rateMu.Unlock()
+	return coreRate
+}
+
+// errRateLimited is returned instead of calling the API while the limit is
+// used up and nothing is cached.
+type errRateLimited struct {
+	reset time.Time
+}
+
+func (e *errRateLimited) Error() string {
+	return fmt.Sprintf("API rate limit exceeded until %s", e.reset.Local().Format("15:04"))
+}
+
+// countdown formats the time left until t as m:ss or h:mm:ss.
+func countdown(t time.Time) string {
+	d := max(time.Until(t).Round(time.Second), 0)
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// cachedListing is the last successful listing of an account, served while
+// the rate limit is used up.
+type cachedListing struct {
+	Time  time.Time            `json:"time"`
+	Repos []*github.Repository `json:"repos"`
+}
+
+func repoCacheFile(user string) string {
+	profile := activeProfileName
+	if profile == "" {
+		profile = "default"
+	}
+	return filepath.Join("cache", profile+"-"+strings.ToLower(user)+".json")
+}
+
+// cacheRepos keeps an anonymous listing for when the limit runs out.
+// Authenticated listings can hold private repositories and aren't written
+// to disk; a token's limit is rarely used up anyway.
+func cacheRepos(user string, repos []*github.Repository) {
+	if githubToken() != "" {
+		return
+	}
+	err := writeStateFile(repoCacheFile(user), cachedListing{Time: time.Now().UTC(), Repos: repos})
+	if err != nil {
+		logger.Warn("could not cache repositories", "user", user, "err", err)
+	}
+}
+
+func cachedRepos(user string) (cachedListing, bool) {
+	var c cachedListing
+	if err := readStateFile(repoCacheFile(user), &c); err != nil {
+		logger.Warn("could not read cached repositories", "user", user, "err", err)
+		return c, false
+	}
+	return c, !c.Time.IsZero()
+}
+
+// fromCache serves user's cached listing while the rate limit is used up.
+func fromCache(user string, reset time.Time) ([]*github.Repository, fetchInfo, error) {
+	c, ok := cachedRepos(user)
+	if !ok {
+		return nil, fetchInfo{rate: currentRate()}, &errRateLimited{reset: reset}
+	}
+	logger.Info("rate limited, serving cached repositories", "user", user, "cached", c.Time, "reset", reset)
+	return c.Repos, fetchInfo{rate: currentRate(), cachedAt: c.Time}, nil
+}
+
+func noteRateErr(err error) {
+	var rle *github.RateLimitError
+	if errors.As(err, &rle) {
+		noteRate(rle.Rate)
+	}
+}
+
+type rateTickMsg struct{}
+
+// wantRateTick starts a one-second tick while the list is served from
+// cache, to count down to the reset and then refresh. Like the refresh
+// cycle, a chain whose tick was dropped behind another screen is restarted.
+func (m *repoModel) wantRateTick() tea.Cmd {
+	if m.info.cachedAt.IsZero() || time.Since(m.rateTicked) < 2*time.Second {
+		return nil
+	}
+	return m.nextRateTick()
+}
+
+func (m *repoModel) nextRateTick() tea.Cmd {
+	m.rateTicked = time.Now()
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return rateTickMsg{} })
+}
+
+// rateTick refreshes the cached list once the limit has reset.
+func (m repoModel) rateTick() (repoModel, tea.Cmd) {
+	if m.info.cachedAt.IsZero() {
+		return m, nil
+	}
+	if _, limited := rateLimited(); limited || m.username == "" {
+		return m, m.nextRateTick()
+	}
+	m.info.cachedAt = time.Time{}
+	return m, m.refreshRepos()
+}
+
+func (m repoModel) rateStatus() string {
+	if reset, limited := rateLimited(); limited {
+		return tr("rate limit resets in %s", countdown(reset))
+	}
+	return ""
+}
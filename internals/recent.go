@@ -0,0 +1,60 @@
+package internals
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+const maxRecentAccounts = 20
+
+var recentMu sync.Mutex
+
+// recordAccount remembers a successfully listed account for shell
+// completion, most recent first.
+func recordAccount(name string) {
+	recentMu.Lock()
+	defer recentMu.Unlock()
+
+	var recent []string
+	if err := readStateFile("recent.json", &recent); err != nil {
+		logger.Warn("could not read recent accounts", "err", err)
+	}
+	recent = slices.DeleteFunc(recent, func(r string) bool { return strings.EqualFold(r, name) })
+	recent = append([]string{name}, recent...)
+	if len(recent) > maxRecentAccounts {
+		recent = recent[:maxRecentAccounts]
+	}
+	if err := writeStateFile("recent.json", recent); err != nil {
+		logger.Warn("could not save recent accounts", "err", err)
+	}
+}
+
+// RecentAccounts lists recently browsed accounts followed by the owners of
+// cloned repositories, without duplicates.
+func RecentAccounts() []string {
+	var recent []string
+	readStateFile("recent.json", &recent)
+
+	entries, _ := readHistory()
+	for _, e := range entries {
+		if owner, _, ok := strings.Cut(e.Repo, "/"); ok && owner != "" {
+			recent = append(recent, owner)
+		}
+	}
+
+	seen := make(map[string]bool)
+	return slices.DeleteFunc(recent, func(r string) bool {
+		k := strings.ToLower(r)
+		if seen[k] {
+			return true
+		}
+		seen[k] = true
+		return false
+	})
+}
+
+// ProfileNames lists the profiles in the config, sorted.
+func ProfileNames() []string {
+	return profileNames()
+}
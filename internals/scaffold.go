@@ -0,0 +1,221 @@
+package internals
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// scaffoldConfig turns a clone of a template repository into a new
+// project by substituting placeholders in its files.
+type scaffoldConfig struct {
+	// Module is the Go module path of new projects, e.g.
+	// "github.com/me/{name}". The template's module path in go.mod is
+	// replaced with it everywhere; empty keeps it.
+	Module string `json:"module"`
+	// Replace maps a placeholder to its value. Values may use {name}, the
+	// project name, and {module}, the new module path.
+	Replace map[string]string `json:"replace"`
+}
+
+// Files larger than this are left alone, they are hardly boilerplate.
+const scaffoldMaxFile = 1 << 20
+
+// goModule reads the module path from dir's go.mod, empty without one.
+func goModule(dir string) string {
+	f, err := os.Open(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(sc.Text()), "module"); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// scaffoldPairs are the old, new pairs for turning dir into project name,
+// longest first so a placeholder never shadows a longer one.
+func scaffoldPairs(dir, name string) []string {
+	expand := strings.NewReplacer("{name}", name).Replace
+	module := expand(cfg.Scaffold.Module)
+	old := goModule(dir)
+	if module == "" {
+		module = old
+	}
+	expand = strings.NewReplacer("{name}", name, "{module}", module).Replace
+
+	subst := make(map[string]string)
+	for placeholder, value := range cfg.Scaffold.Replace {
+		if placeholder != "" {
+			subst[placeholder] = expand(value)
+		}
+	}
+	if old != "" && module != old {
+		subst[old] = module
+	}
+	keys := make([]string, 0, len(subst))
+	for k := range subst {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		if len(keys[a]) != len(keys[b]) {
+			return len(keys[a]) > len(keys[b])
+		}
+		return keys[a] < keys[b]
+	})
+	var pairs []string
+	for _, k := range keys {
+		if subst[k] != k {
+			pairs = append(pairs, k, subst[k])
+		}
+	}
+	return pairs
+}
+
+// scaffold substitutes the configured placeholders and the template's
+// module path in the text files below dir, and in file and directory
+// names, returning how many files changed. .git is skipped, so the
+// template's history stays.
+func scaffold(dir, name string) (int, error) {
+	pairs := scaffoldPairs(dir, name)
+	if len(pairs) == 0 {
+		return 0, fmt.Errorf("nothing to substitute in %s: set scaffold.module or scaffold.replace in the config", dir)
+	}
+	r := strings.NewReplacer(pairs...)
+
+	changed := 0
+	var renames []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if path != dir && r.Replace(d.Name()) != d.Name() {
+			renames = append(renames, path)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > scaffoldMaxFile {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+			return nil // binary
+		}
+		out := r.Replace(string(data))
+		if out == string(data) {
+			return nil
+		}
+		changed++
+		return os.WriteFile(path, []byte(out), info.Mode().Perm())
+	})
+	if err != nil {
+		return changed, err
+	}
+
+	// deepest first, so renaming a directory doesn't move what's left
+	for i := len(renames) - 1; i >= 0; i-- {
+		path := renames[i]
+		dest := filepath.Join(filepath.Dir(path), r.Replace(filepath.Base(path)))
+		if _, err := os.Lstat(dest); err == nil {
+			return changed, fmt.Errorf("can't rename %s: %s exists", path, dest)
+		}
+		if err := os.Rename(path, dest); err != nil {
+			return changed, err
+		}
+	}
+	logger.Debug("scaffolded", "dir", dir, "name", name, "files", changed, "renamed", len(renames))
+	return changed, nil
+}
+
+// Scaffold turns the clone in dir into project name, defaulting to the
+// directory's name.
+func Scaffold(dir, name string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+	n, err := scaffold(dir, name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("scaffolded %s as %s: %d files changed\n", dir, name, n)
+	return nil
+}
+
+type scaffoldDoneMsg struct {
+	dir   string
+	name  string
+	files int
+	err   error
+}
+
+func (m repoModel) startScaffold() (tea.Model, tea.Cmd) {
+	ti := textinput.New()
+	ti.Prompt = tr("project name: ")
+	ti.CharLimit = 128
+	ti.SetValue(filepath.Base(m.lastClone))
+	ti.Focus()
+	m.scaffoldInput = ti
+	m.scaffolding = true
+	return m, textinput.Blink
+}
+
+func (m repoModel) updateScaffold(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.scaffolding = false
+		return m, nil
+	case tea.KeyEnter:
+		name := strings.TrimSpace(m.scaffoldInput.Value())
+		if name == "" {
+			return m, nil
+		}
+		m.scaffolding = false
+		dir := m.lastClone
+		return m, func() tea.Msg {
+			n, err := scaffold(dir, name)
+			return scaffoldDoneMsg{dir: dir, name: name, files: n, err: err}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.scaffoldInput, cmd = m.scaffoldInput.Update(msg)
+	return m, cmd
+}
+
+func (m repoModel) scaffoldDone(msg scaffoldDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.cloneError = true
+		m.cloneMsg = tr("Error scaffolding: %v", msg.err)
+		return m, nil
+	}
+	m.cloneError = false
+	m.cloneMsg = tr("Scaffolded %s as %s: %d files changed", msg.dir, msg.name, msg.files)
+	return m, nil
+}
@@ -0,0 +1,293 @@
+package internals
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v50/github"
+)
+
+// press turns a key name into the message bubbletea sends for it.
+func press(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "ctrl+u":
+		return tea.KeyMsg{Type: tea.KeyCtrlU}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// update hands msg to m and keeps its type, failing when the screen was
+// replaced by another.
+func update[M tea.Model](t *testing.T, m M, msg tea.Msg) (M, tea.Cmd) {
+	t.Helper()
+	next, cmd := m.Update(msg)
+	got, ok := next.(M)
+	if !ok {
+		t.Fatalf("%T became %T", m, next)
+	}
+	return got, cmd
+}
+
+func TestWebhooksModel(t *testing.T) {
+	fake := &fakeGitHub{Hooks: map[string][]*github.Hook{
+		"alice/tool": {{ID: github.Int64(1), Config: map[string]any{"url": "https://ci.example.com/hook"}, Events: []string{"push"}, Active: github.Bool(true)}},
+	}}
+	useFake(t, fake)
+
+	m := newWebhooksModel(item{owner: "alice", name: "tool"})
+	m, _ = update(t, m, runUntil[hooksFetchedMsg](t, m.Init()))
+	if len(m.list.Items()) != 1 || m.list.Items()[0].(webhook).url != "https://ci.example.com/hook" {
+		t.Fatalf("items = %v, want the ci hook", m.list.Items())
+	}
+
+	m, cmd := update(t, m, press("p"))
+	m, cmd = update(t, m, runUntil[hookDoneMsg](t, cmd))
+	if len(fake.Pings) != 1 || fake.Pings[0] != 1 {
+		t.Errorf("pings = %v, want [1]", fake.Pings)
+	}
+	m, _ = update(t, m, runUntil[hooksFetchedMsg](t, cmd))
+
+	m, _ = update(t, m, press("a"))
+	m, _ = update(t, m, press("https://chat.example.com/in push,release"))
+	m, cmd = update(t, m, press("enter"))
+	done := runUntil[hookDoneMsg](t, cmd)
+	if done.err != nil {
+		t.Fatal(done.err)
+	}
+	m, cmd = update(t, m, done)
+	m, _ = update(t, m, runUntil[hooksFetchedMsg](t, cmd))
+	if hooks := fake.Hooks["alice/tool"]; len(hooks) != 2 || strings.Join(hooks[1].Events, ",") != "push,release" {
+		t.Fatalf("hooks after adding = %v", hooks)
+	}
+	if len(m.list.Items()) != 2 {
+		t.Errorf("list shows %d hooks after adding, want 2", len(m.list.Items()))
+	}
+
+	m, _ = update(t, m, press("d"))
+	m, cmd = update(t, m, press("y"))
+	if done := runUntil[hookDoneMsg](t, cmd); done.err != nil {
+		t.Fatal(done.err)
+	}
+	if hooks := fake.Hooks["alice/tool"]; len(hooks) != 1 || hooks[0].GetID() != 2 {
+		t.Errorf("hooks after deleting the first = %v", hooks)
+	}
+}
+
+func TestKeysModel(t *testing.T) {
+	fake := &fakeGitHub{Keys: map[string][]*github.Key{
+		"alice/tool": {{ID: github.Int64(1), Title: github.String("ci"), Key: github.String("ssh-ed25519 AAAAci"), ReadOnly: github.Bool(true)}},
+		"":           {{ID: github.Int64(1), Title: github.String("laptop"), Key: github.String("ssh-ed25519 AAAAlaptop")}},
+	}}
+	useFake(t, fake)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".ssh"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".ssh", "id_ed25519.pub"), []byte("ssh-ed25519 AAAAnew me@host\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newKeysModel(item{owner: "alice", name: "tool"})
+	m, _ = update(t, m, runUntil[keysFetchedMsg](t, m.Init()))
+	if len(m.list.Items()) != 1 || !m.list.Items()[0].(sshKey).deploy {
+		t.Fatalf("deploy keys = %v", m.list.Items())
+	}
+
+	m, cmd := update(t, m, press("tab"))
+	m, _ = update(t, m, runUntil[keysFetchedMsg](t, cmd))
+	if !m.account || len(m.list.Items()) != 1 || m.list.Items()[0].(sshKey).title != "laptop" {
+		t.Fatalf("account keys = %v", m.list.Items())
+	}
+
+	// a uploads ~/.ssh/id_ed25519.pub, the prompt's default
+	m, _ = update(t, m, press("a"))
+	m, cmd = update(t, m, press("enter"))
+	if done := runUntil[keyDoneMsg](t, cmd); done.err != nil {
+		t.Fatal(done.err)
+	}
+	if keys := fake.Keys[""]; len(keys) != 2 || keys[1].GetKey() != "ssh-ed25519 AAAAnew" {
+		t.Fatalf("account keys after uploading = %v", keys)
+	}
+
+	m, _ = update(t, m, press("tab"))
+	m, _ = update(t, m, runUntil[keysFetchedMsg](t, m.fetch()))
+	m, _ = update(t, m, press("d"))
+	_, cmd = update(t, m, press("y"))
+	if done := runUntil[keyDoneMsg](t, cmd); done.err != nil {
+		t.Fatal(done.err)
+	}
+	if keys := fake.Keys["alice/tool"]; len(keys) != 0 {
+		t.Errorf("deploy keys after deleting = %v", keys)
+	}
+}
+
+func TestFetchTraffic(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	useFake(t, &fakeGitHub{
+		Views: map[string]*github.TrafficViews{"alice/tool": {
+			Count: github.Int(7), Uniques: github.Int(3),
+			Views: []*github.TrafficData{{Timestamp: &github.Timestamp{Time: today}, Count: github.Int(7), Uniques: github.Int(3)}},
+		}},
+		Clones: map[string]*github.TrafficClones{"alice/tool": {Count: github.Int(2), Uniques: github.Int(1)}},
+	})
+
+	msg := runUntil[trafficFetchedMsg](t, fetchTraffic("alice", "tool"))
+	if msg.err != nil {
+		t.Fatal(msg.err)
+	}
+	if msg.views.total != 7 || msg.views.uniques != 3 || msg.clones.total != 2 {
+		t.Errorf("views %+v, clones %+v", msg.views, msg.clones)
+	}
+	if last := msg.views.daily[len(msg.views.daily)-1]; last != 7 {
+		t.Errorf("today's views = %d, want 7 (daily %v)", last, msg.views.daily)
+	}
+
+	if msg := runUntil[trafficFetchedMsg](t, fetchTraffic("alice", "secret")); msg.err == nil {
+		t.Error("traffic of a repository without access succeeded, want an error")
+	}
+}
+
+func TestTeamsModel(t *testing.T) {
+	useFake(t, &fakeGitHub{
+		Teams: map[string][]*github.Team{"corp": {{Slug: github.String("infra"), Name: github.String("Infra")}}},
+		TeamRepos: map[string][]*github.Repository{
+			"corp/infra": {fakeRepo("corp", "deploy", ""), fakeRepo("partner", "shared", "")},
+		},
+	})
+
+	m := newTeamsModel("corp", fetchInfo{})
+	m, _ = update(t, m, runUntil[teamsFetchedMsg](t, m.Init()))
+	if len(m.list.Items()) != 1 {
+		t.Fatalf("teams = %v", m.list.Items())
+	}
+	m, cmd := update(t, m, press("enter"))
+	if m.opening != "infra" {
+		t.Errorf("opening = %q while the team's repositories load", m.opening)
+	}
+	loaded := runUntil[pageLoadedMsg](t, cmd)
+	if loaded.err != nil {
+		t.Fatal(loaded.err)
+	}
+	rm := loaded.model.(repoModel)
+	var names []string
+	for _, li := range rm.list.Items() {
+		it := li.(item)
+		names = append(names, it.name)
+		if it.showOwner != (it.owner != "corp") {
+			t.Errorf("%s/%s showOwner = %v", it.owner, it.name, it.showOwner)
+		}
+	}
+	if strings.Join(names, ",") != "deploy,shared" {
+		t.Errorf("team repositories = %v", names)
+	}
+}
+
+func TestEventsModel(t *testing.T) {
+	event := func(kind, repo string) *github.Event {
+		payload := json.RawMessage("{}")
+		return &github.Event{Type: github.String(kind), Repo: &github.Repository{Name: github.String(repo)}, RawPayload: &payload, CreatedAt: &github.Timestamp{Time: time.Now()}}
+	}
+	useFake(t, &fakeGitHub{
+		Events: map[string][]*github.Event{"bob": {event("PushEvent", "bob/a"), event("WatchEvent", "carol/c")}},
+		Repos:  map[string][]*github.Repository{"carol": {fakeRepo("carol", "c", "")}},
+	})
+
+	m := newEventsModel("bob")
+	m, _ = update(t, m, runUntil[eventsFetchedMsg](t, m.Init()))
+	if len(m.list.Items()) != 2 {
+		t.Fatalf("events = %v", m.list.Items())
+	}
+
+	// bob's own repository is selected in the list below
+	_, cmd := update(t, m, press("enter"))
+	back, ok := cmd().(navBackMsg)
+	if sel, _ := back.then.(selectRepoMsg); !ok || sel.name != "a" {
+		t.Errorf("enter on bob/a sent %#v, want back to select a", back)
+	}
+
+	// carol's opens her list as a new page
+	m.list.Select(1)
+	m, cmd = update(t, m, press("enter"))
+	loaded := runUntil[pageLoadedMsg](t, cmd)
+	if loaded.err != nil {
+		t.Fatal(loaded.err)
+	}
+	if rm, ok := loaded.model.(repoModel); !ok || rm.username != "carol" {
+		t.Fatalf("enter on carol/c loaded %T", loaded.model)
+	}
+	if _, cmd = update(t, m, loaded); cmd == nil {
+		t.Error("the loaded page is not opened")
+	}
+}
+
+func TestNotificationsModel(t *testing.T) {
+	fake := &fakeGitHub{Notes: []*github.Notification{{
+		ID:         github.String("7"),
+		Unread:     github.Bool(true),
+		Reason:     github.String("review_requested"),
+		Subject:    &github.NotificationSubject{Title: github.String("Fix the build"), Type: github.String("PullRequest")},
+		Repository: &github.Repository{FullName: github.String("bob/a")},
+	}}}
+	useFake(t, fake)
+
+	m := newNotificationsModel("bob")
+	m, _ = update(t, m, runUntil[notificationsFetchedMsg](t, m.Init()))
+	if len(m.list.Items()) != 1 {
+		t.Fatalf("notifications = %v", m.list.Items())
+	}
+	m, cmd := update(t, m, press("r"))
+	m, _ = update(t, m, runUntil[threadReadMsg](t, cmd))
+	if fake.Notes[0].GetUnread() {
+		t.Error("the thread is still unread on the server")
+	}
+	if m.list.Items()[0].(notification).unread {
+		t.Error("the list still shows the thread as unread")
+	}
+}
+
+func TestContributorsModel(t *testing.T) {
+	useFake(t, &fakeGitHub{
+		Contribs: map[string][]*github.Contributor{"bob/a": {{Login: github.String("carol"), Contributions: github.Int(42)}}},
+		Repos:    map[string][]*github.Repository{"carol": {fakeRepo("carol", "c", "")}},
+	})
+
+	m := newContributorsModel(item{owner: "bob", name: "a"})
+	m, _ = update(t, m, runUntil[contributorsFetchedMsg](t, m.Init()))
+	if len(m.list.Items()) != 1 || m.list.Items()[0].(contributor).commits != 42 {
+		t.Fatalf("contributors = %v", m.list.Items())
+	}
+	_, cmd := update(t, m, press("enter"))
+	push, ok := cmd().(navPushMsg)
+	if rm, isList := push.model.(repoModel); !ok || !isList || rm.username != "carol" {
+		t.Errorf("enter opened %#v, want carol's repositories", push.model)
+	}
+}
+
+func TestTagsModel(t *testing.T) {
+	useFake(t, &fakeGitHub{Tags: map[string][]*github.RepositoryTag{
+		"bob/a": {{Name: github.String("v1.2.0"), Commit: &github.Commit{SHA: github.String("0123456789abcdef")}}},
+	}})
+
+	m := newTagsModel(item{owner: "bob", name: "a"})
+	m, _ = update(t, m, runUntil[tagsFetchedMsg](t, m.Init()))
+	if len(m.list.Items()) != 1 || m.list.Items()[0].(tag).commit != "0123456" {
+		t.Fatalf("tags = %v", m.list.Items())
+	}
+	_, cmd := update(t, m, press("enter"))
+	back, ok := cmd().(navBackMsg)
+	if clone, _ := back.then.(cloneAtTagMsg); !ok || clone.tag != "v1.2.0" {
+		t.Errorf("enter sent %#v, want back to clone at v1.2.0", back)
+	}
+}
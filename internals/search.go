@@ -0,0 +1,189 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v50/github"
+)
+
+// searchDebounce is how long typing has to pause before a search is sent.
+const searchDebounce = 300 * time.Millisecond
+
+type searchTickMsg struct{ seq int }
+
+type searchResultsMsg struct {
+	seq   int
+	repos []*github.Repository
+	total int
+	err   error
+}
+
+func searchRepos(username, query string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		if activeProfile.provider() != providerGitHub {
+			return searchResultsMsg{seq: seq, err: fmt.Errorf("remote search is only supported for GitHub profiles")}
+		}
+		ctx := context.Background()
+		q := fmt.Sprintf("user:%s fork:true %s", username, query)
+		opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 50}}
+		res, _, err := newAPI().SearchRepos(ctx, q, opts)
+		if err != nil {
+			return searchResultsMsg{seq: seq, err: fmt.Errorf("search failed: %w", err)}
+		}
+		return searchResultsMsg{seq: seq, repos: res.Repositories, total: res.GetTotal()}
+	}
+}
+
+// searchModel queries the search API as the user types, so large accounts
+// can be searched without listing every repository first.
+type searchModel struct {
+	username  string
+	input     textinput.Model
+	seq       int
+	searching bool
+	results   []*github.Repository
+	total     int
+	cursor    int
+	err       error
+	width     int
+	height    int
+}
+
+func newSearchModel(username string) searchModel {
+	ti := textinput.New()
+	ti.Placeholder = tr("search %s's repositories", username)
+	ti.Focus()
+	ti.CharLimit = 128
+	return searchModel{username: username, input: ti}
+}
+
+func (m searchModel) crumbs() []string { return []string{"search"} }
+
+func (m searchModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m searchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m, goBack
+		case "up", "ctrl+p":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if m.cursor < len(m.results)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			return m.openResults()
+		}
+
+		prev := m.input.Value()
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		if m.input.Value() == prev {
+			return m, cmd
+		}
+		m.seq++
+		if strings.TrimSpace(m.input.Value()) == "" {
+			m.searching = false
+			m.results, m.total, m.err = nil, 0, nil
+			return m, cmd
+		}
+		seq := m.seq
+		return m, tea.Batch(cmd, tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+			return searchTickMsg{seq}
+		}))
+	case searchTickMsg:
+		if msg.seq != m.seq {
+			return m, nil
+		}
+		m.searching = true
+		return m, searchRepos(m.username, strings.TrimSpace(m.input.Value()), m.seq)
+	case searchResultsMsg:
+		if msg.seq != m.seq {
+			// a newer query is in flight
+			return m, nil
+		}
+		m.searching = false
+		m.results, m.total, m.err = msg.repos, msg.total, msg.err
+		m.cursor = 0
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// openResults hands the results to a regular repository list so every
+// action (clone, sparse, pin, notes) works on them; esc comes back here.
+func (m searchModel) openResults() (tea.Model, tea.Cmd) {
+	if len(m.results) == 0 {
+		return m, nil
+	}
+	items := make([]list.Item, len(m.results))
+	for i, repo := range m.results {
+		items[i] = newItem(repo)
+	}
+	rm := newRepoModel(tr("%s's repositories matching %q", m.username, m.input.Value()), items)
+	rm.username = m.username
+	rm.repos = m.results
+	rm.crumb = "search " + m.input.Value()
+	rm.list.Select(m.cursor)
+	return m, navigate(rm)
+}
+
+func (m searchModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.input.View() + "\n\n")
+
+	switch {
+	case m.err != nil:
+		b.WriteString(errorStyle.Render(m.err.Error()))
+	case m.searching:
+		b.WriteString(tr("Searching..."))
+	case strings.TrimSpace(m.input.Value()) == "":
+		b.WriteString(tr("Type to search."))
+	case len(m.results) == 0:
+		b.WriteString(tr("No matching repositories."))
+	default:
+		rows := len(m.results)
+		if m.height > 0 {
+			// input, blank line, footer and margins
+			rows = min(rows, max(m.height-8, 1))
+		}
+		start := max(0, m.cursor-rows+1)
+		for i := start; i < start+rows && i < len(m.results); i++ {
+			it := newItem(m.results[i])
+			line := it.Title() + "  " + badgeStyle.Render(it.Description())
+			if i == m.cursor {
+				line = cursorStyle.Render("> ") + line
+			} else {
+				line = "  " + line
+			}
+			b.WriteString(line + "\n")
+		}
+		if m.total > len(m.results) {
+			fmt.Fprintf(&b, "\n%d of %d matches, refine the query to narrow them down", len(m.results), m.total)
+		}
+	}
+
+	b.WriteString("\n\n" + tr("enter: open results · ↑/↓: move · esc: back"))
+	return normalStyle.Render(b.String())
+}
@@ -0,0 +1,298 @@
+package internals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/google/go-github/v50/github"
+)
+
+const sessionVersion = 1
+
+// session is a recording of what was done in one run of gitls. Replaying it
+// repeats the clones, so a browsing session doubles as a setup script.
+type session struct {
+	Version int             `json:"version"`
+	Started time.Time       `json:"started"`
+	Profile string          `json:"profile,omitempty"`
+	Actions []sessionAction `json:"actions"`
+}
+
+// sessionAction is one step: "user" opened an account, "filter" and "sort"
+// changed the list and "clone" cloned a repository.
+type sessionAction struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	User   string    `json:"user,omitempty"`
+	Query  string    `json:"query,omitempty"`      // list filter text
+	Attrs  string    `json:"attributes,omitempty"` // attribute filter, e.g. "topic=cli"
+	Sort   string    `json:"sort,omitempty"`
+
+	Repo   string   `json:"repo,omitempty"` // owner/name
+	URL    string   `json:"url,omitempty"`
+	Dir    string   `json:"dir,omitempty"` // relative to the clone dir when below it
+	Mirror bool     `json:"mirror,omitempty"`
+	Depth  int      `json:"depth,omitempty"`
+	Filter string   `json:"clone_filter,omitempty"`
+	Branch string   `json:"branch,omitempty"`
+	Sparse []string `json:"sparse,omitempty"` // sparse checkout directories
+	Env    []string `json:"env,omitempty"`
+}
+
+var (
+	sessionMu   sync.Mutex
+	sessionPath string
+	recording   *session
+)
+
+// StartRecording logs every account opened, list filter and clone of this
+// run to path, replacing what is there.
+func StartRecording(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	sessionPath = path
+	recording = &session{Version: sessionVersion, Started: time.Now().UTC().Truncate(time.Second), Profile: activeProfileName}
+	return writeSession()
+}
+
+func writeSession() error {
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := sessionPath + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, sessionPath)
+}
+
+// recordAction appends a to the recording, if there is one. Like the clone
+// history it never fails what was recorded.
+func recordAction(a sessionAction) {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	if recording == nil {
+		return
+	}
+	if a.Action == "user" {
+		// refreshes list the same account again
+		for i := len(recording.Actions) - 1; i >= 0; i-- {
+			if prev := recording.Actions[i]; prev.Action == "user" {
+				if strings.EqualFold(prev.User, a.User) {
+					return
+				}
+				break
+			}
+		}
+	}
+	a.Time = time.Now().UTC().Truncate(time.Second)
+	recording.Actions = append(recording.Actions, a)
+	if err := writeSession(); err != nil {
+		logger.Warn("could not record session", "path", sessionPath, "err", err)
+	}
+}
+
+func recordSessionClone(repo, url, dir string, opts gitls.CloneOptions, sparse []string) {
+	recordAction(sessionAction{
+		Action: "clone",
+		Repo:   repo,
+		URL:    url,
+		Dir:    sessionDir(dir),
+		Mirror: opts.Mirror,
+		Depth:  opts.Depth,
+		Filter: opts.Filter,
+		Branch: opts.Branch,
+		Sparse: sparse,
+		Env:    opts.Env,
+	})
+}
+
+// sessionDir records dir relative to the clone dir, or to ~, so a replay
+// on another machine lands in the same place there.
+func sessionDir(dir string) string {
+	dir = absCloneDir(dir)
+	if rel, err := filepath.Rel(absCloneDir(DefaultCloneDir()), dir); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(home, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			return "~/" + filepath.ToSlash(rel)
+		}
+	}
+	return dir
+}
+
+// replayDir is where a recorded dir goes on this machine. A session file
+// may come from someone else, so it can only clone below the clone dir or
+// ~, never elsewhere.
+func replayDir(recorded string) (string, error) {
+	root, dir := absCloneDir(DefaultCloneDir()), recorded
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		root, dir = home, strings.TrimPrefix(strings.TrimPrefix(dir, "~"), "/")
+	}
+	if dir == "" || filepath.IsAbs(dir) {
+		return "", fmt.Errorf("%q is not below the clone directory or ~; not replayed", recorded)
+	}
+	dest := filepath.Join(root, filepath.FromSlash(dir))
+	if rel, err := filepath.Rel(root, dest); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%q leaves %s; not replayed", recorded, root)
+	}
+	return dest, nil
+}
+
+// replayEnv are the environment variables a replay passes on to git. The
+// rest could run commands, e.g. GIT_SSH_COMMAND or GIT_CONFIG_*.
+var replayEnv = map[string]bool{
+	"GIT_LFS_SKIP_SMUDGE=1": true,
+}
+
+// checkReplay refuses what a recorded clone could use to run commands:
+// environment variables beyond replayEnv, and a URL or sparse directory
+// git would take for an option.
+func checkReplay(a sessionAction) error {
+	for _, e := range a.Env {
+		if !replayEnv[e] {
+			return fmt.Errorf("environment %q is not allowed in a replay", e)
+		}
+	}
+	for _, arg := range append([]string{a.URL}, a.Sparse...) {
+		if strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("%q is not allowed in a replay", arg)
+		}
+	}
+	return nil
+}
+
+// recordListChange records how the list changed between two states of the
+// repository view.
+func recordListChange(before, after repoModel) {
+	sessionMu.Lock()
+	on := recording != nil
+	sessionMu.Unlock()
+	if !on {
+		return
+	}
+	if q := after.appliedFilter(); q != before.appliedFilter() {
+		recordAction(sessionAction{Action: "filter", Query: q})
+	}
+	if after.filter != before.filter {
+		recordAction(sessionAction{Action: "filter", Attrs: after.filter.String()})
+	}
+	if after.sort != before.sort {
+		recordAction(sessionAction{Action: "sort", Sort: after.sort.String()})
+	}
+}
+
+// appliedFilter is the list filter text while one is applied.
+func (m repoModel) appliedFilter() string {
+	if m.list.FilterState() != list.FilterApplied {
+		return ""
+	}
+	return m.list.FilterValue()
+}
+
+func readSession(path string) (*session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if s.Version != sessionVersion {
+		return nil, fmt.Errorf("%s: unsupported session version %d", path, s.Version)
+	}
+	return &s, nil
+}
+
+// Replay repeats the clones recorded in the session at path, one after
+// another and without the TUI. Clones that are already there are skipped,
+// so replaying twice changes nothing.
+func Replay(path string) error {
+	s, err := readSession(path)
+	if err != nil {
+		return err
+	}
+	if s.Profile != "" && s.Profile != activeProfileName {
+		if err := UseProfile(s.Profile); err != nil {
+			return err
+		}
+	}
+
+	var repos []*github.Repository
+	actions := make(map[*github.Repository]sessionAction)
+	for _, a := range s.Actions {
+		if a.Action != "clone" {
+			continue
+		}
+		owner, name, _ := strings.Cut(a.Repo, "/")
+		repo := &github.Repository{
+			Name:     github.String(name),
+			FullName: github.String(a.Repo),
+			CloneURL: github.String(a.URL),
+			Owner:    &github.User{Login: github.String(owner)},
+		}
+		repos = append(repos, repo)
+		actions[repo] = a
+	}
+	if len(repos) == 0 {
+		return fmt.Errorf("%s has no clones to replay", path)
+	}
+	fmt.Printf("replaying %d clones from %s\n", len(repos), path)
+	return runRepos("session", repos, BatchOptions{Concurrency: 1}, func(repo *github.Repository, _ BatchOptions) batchResult {
+		return replayClone(repo, actions[repo])
+	})
+}
+
+func replayClone(repo *github.Repository, a sessionAction) batchResult {
+	dest, err := replayDir(a.Dir)
+	if err == nil {
+		err = checkReplay(a)
+	}
+	if err != nil {
+		return batchResult{name: a.Repo, status: batchFailed, err: err}
+	}
+	it := newItem(repo)
+	if reason, cloned := checkDest(it, dest); cloned {
+		return batchResult{name: a.Repo, status: batchSkipped}
+	} else if reason != "" {
+		return batchResult{name: a.Repo, status: batchFailed, err: fmt.Errorf("%s/ %s", dest, reason)}
+	}
+	if err := runHook(newHookPayload(hookPreClone, it, dest)); err != nil {
+		return batchResult{name: a.Repo, status: batchSkipped, err: err}
+	}
+
+	opts := gitls.CloneOptions{Mirror: a.Mirror, Depth: a.Depth, Filter: a.Filter, Branch: a.Branch, Sparse: len(a.Sparse) > 0, Env: a.Env}
+	err = gitRunner().Clone(context.Background(), a.URL, dest, opts)
+	if err == nil && len(a.Sparse) > 0 {
+		_, err = runGit(append([]string{"-C", dest, "sparse-checkout", "set"}, a.Sparse...)...)
+	}
+	recordClone(a.Repo, a.URL, dest, err)
+	if err != nil {
+		return batchResult{name: a.Repo, status: batchFailed, err: errors.New(gitFailure(err, a.URL, dest))}
+	}
+	bytes := gitDirSize(dest, a.Mirror)
+	if err := runHook(newHookPayload(hookPostClone, it, dest)); err != nil {
+		return batchResult{name: a.Repo, status: batchFailed, err: err, bytes: bytes}
+	}
+	return batchResult{name: a.Repo, status: batchCloned, bytes: bytes}
+}
@@ -0,0 +1,68 @@
+package internals
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplayDir(t *testing.T) {
+	home, root := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	prev := activeProfile
+	t.Cleanup(func() { activeProfile = prev })
+	activeProfile = profile{CloneDir: root}
+
+	tests := []struct {
+		recorded string
+		want     string // empty when refused
+	}{
+		{"tool", filepath.Join(root, "tool")},
+		{"github.com/alice/tool", filepath.Join(root, "github.com", "alice", "tool")},
+		{"~/src/tool", filepath.Join(home, "src", "tool")},
+		{"/etc/cron.d", ""},
+		{filepath.Join(t.TempDir(), "tool"), ""},
+		{"../outside", ""},
+		{"a/../../outside", ""},
+		{"~/../outside", ""},
+		{"~", ""},
+		{".", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := replayDir(tt.recorded)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("replayDir(%q) = %q, want an error", tt.recorded, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("replayDir(%q) = %q, %v, want %q", tt.recorded, got, err, tt.want)
+		}
+	}
+}
+
+func TestCheckReplay(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  sessionAction
+		wantErr string
+	}{
+		{"plain", sessionAction{URL: "https://github.com/a/b.git"}, ""},
+		{"lfs skip", sessionAction{URL: "https://github.com/a/b.git", Env: []string{"GIT_LFS_SKIP_SMUDGE=1"}}, ""},
+		{"ssh command", sessionAction{URL: "git@github.com:a/b.git", Env: []string{"GIT_SSH_COMMAND=touch /tmp/pwned"}}, "environment"},
+		{"git config", sessionAction{URL: "https://github.com/a/b.git", Env: []string{"GIT_CONFIG_COUNT=1"}}, "environment"},
+		{"option url", sessionAction{URL: "--upload-pack=touch /tmp/pwned"}, "not allowed"},
+		{"option sparse dir", sessionAction{URL: "https://github.com/a/b.git", Sparse: []string{"docs", "--stdin"}}, "not allowed"},
+	}
+	for _, tt := range tests {
+		err := checkReplay(tt.action)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: %v", tt.name, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
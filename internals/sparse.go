@@ -0,0 +1,175 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var cursorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+
+type treeFetchedMsg struct {
+	dirs []string
+	err  error
+}
+
+func fetchTopLevelDirs(owner, repo, branch string) tea.Cmd {
+	return func() tea.Msg {
+		if activeProfile.provider() != providerGitHub {
+			return treeFetchedMsg{err: fmt.Errorf("directory listing is only supported for GitHub profiles")}
+		}
+		ctx := context.Background()
+		tree, _, err := newAPI().GetTree(ctx, owner, repo, branch)
+		if err != nil {
+			return treeFetchedMsg{err: fmt.Errorf("failed to fetch tree: %w", err)}
+		}
+
+		var dirs []string
+		for _, entry := range tree.Entries {
+			if entry.GetType() == "tree" {
+				dirs = append(dirs, entry.GetPath())
+			}
+		}
+		sort.Strings(dirs)
+		return treeFetchedMsg{dirs: dirs}
+	}
+}
+
+func cloneSparse(it item, paths []string) tea.Cmd {
+	return func() tea.Msg {
+		dest := cloneTarget(it)
+		msg := cloneFinishedMsg{repo: it.owner + "/" + it.name, url: it.url, fork: it.fork, template: it.template, target: dest, sparse: paths}
+		if err := runHook(newHookPayload(hookPreClone, it, dest)); err != nil {
+			msg.err = err
+			return msg
+		}
+		if reason, cloned := checkDest(it, dest); cloned {
+			msg.err = fmt.Errorf("already cloned in %s/", dest)
+			return msg
+		} else if reason != "" {
+			msg.err = fmt.Errorf("%s/ %s", dest, reason)
+			return msg
+		}
+		opts := gitls.CloneOptions{Filter: "blob:none", Sparse: true}
+		msg.opts = opts
+		if err := gitRunner().Clone(context.Background(), it.url, dest, opts); err != nil {
+			msg.err = err
+			return msg
+		}
+		args := append([]string{"-C", dest, "sparse-checkout", "set"}, paths...)
+		if _, err := runGit(args...); err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.dir = dest
+		msg.hookErr = runHook(newHookPayload(hookPostClone, it, dest))
+		return msg
+	}
+}
+
+// sparseCloneMsg tells the repository list below to sparse clone it with
+// only paths checked out.
+type sparseCloneMsg struct {
+	it    item
+	paths []string
+}
+
+type sparseModel struct {
+	it       item
+	dirs     []string
+	selected map[string]bool
+	cursor   int
+	loading  bool
+	err      error
+}
+
+func newSparseModel(it item) sparseModel {
+	return sparseModel{
+		it:       it,
+		selected: make(map[string]bool),
+		loading:  true,
+	}
+}
+
+func (m sparseModel) crumbs() []string { return []string{"sparse checkout"} }
+
+func (m sparseModel) Init() tea.Cmd {
+	return fetchTopLevelDirs(m.it.owner, m.it.name, m.it.defaultBranch)
+}
+
+func (m sparseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case treeFetchedMsg:
+		m.loading = false
+		m.dirs = msg.dirs
+		m.err = msg.err
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			return m, goBack
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.dirs)-1 {
+				m.cursor++
+			}
+		case " ", "x":
+			if len(m.dirs) > 0 {
+				dir := m.dirs[m.cursor]
+				m.selected[dir] = !m.selected[dir]
+			}
+		case "enter":
+			var paths []string
+			for _, dir := range m.dirs {
+				if m.selected[dir] {
+					paths = append(paths, dir)
+				}
+			}
+			if len(paths) == 0 {
+				return m, nil
+			}
+			return m, backWith(sparseCloneMsg{it: m.it, paths: paths})
+		}
+	}
+	return m, nil
+}
+
+func (m sparseModel) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sparse checkout of %s — pick top-level directories\n\n", m.it.name)
+
+	switch {
+	case m.loading:
+		b.WriteString(tr("Loading tree..."))
+	case m.err != nil:
+		b.WriteString(errorStyle.Render(m.err.Error()))
+	case len(m.dirs) == 0:
+		b.WriteString(tr("This repository has no top-level directories."))
+	default:
+		for i, dir := range m.dirs {
+			check := "[ ]"
+			if m.selected[dir] {
+				check = "[x]"
+			}
+			line := fmt.Sprintf("%s %s/", check, dir)
+			if i == m.cursor {
+				line = cursorStyle.Render("> " + line)
+			} else {
+				line = "  " + line
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	b.WriteString("\n\n" + tr("space: toggle · enter: clone selected · esc: back"))
+	return normalStyle.Render(b.String())
+}
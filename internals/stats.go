@@ -0,0 +1,124 @@
+package internals
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	barStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	statsTitleStyle = lipgloss.NewStyle().Bold(true).MarginBottom(1)
+)
+
+const statsBarWidth = 30
+
+type statsModel struct {
+	title string
+	items []item
+}
+
+func newStatsModel(title string, listItems []list.Item) statsModel {
+	items := make([]item, 0, len(listItems))
+	for _, li := range listItems {
+		if it, ok := li.(item); ok {
+			items = append(items, it)
+		}
+	}
+	return statsModel{title: title, items: items}
+}
+
+func (m statsModel) crumbs() []string { return []string{"stats"} }
+
+func (m statsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m statsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q", "i":
+			return m, goBack
+		}
+	}
+	return m, nil
+}
+
+type languageCount struct {
+	name  string
+	count int
+}
+
+func (m statsModel) languages() []languageCount {
+	counts := make(map[string]int)
+	for _, it := range m.items {
+		lang := it.language
+		if lang == "" {
+			lang = "other"
+		}
+		counts[lang]++
+	}
+
+	langs := make([]languageCount, 0, len(counts))
+	for name, count := range counts {
+		langs = append(langs, languageCount{name, count})
+	}
+	sort.Slice(langs, func(a, b int) bool {
+		if langs[a].count != langs[b].count {
+			return langs[a].count > langs[b].count
+		}
+		return langs[a].name < langs[b].name
+	})
+	return langs
+}
+
+func (m statsModel) View() string {
+	var stars, forks int
+	for _, it := range m.items {
+		stars += it.stars
+		if it.fork {
+			forks++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(statsTitleStyle.Render(tr("Statistics for %s", m.title)) + "\n")
+	b.WriteString(detailRow("repos", fmt.Sprint(len(m.items))) + "\n")
+	b.WriteString(detailRow("stars", fmt.Sprint(stars)) + "\n")
+	if len(m.items) > 0 {
+		b.WriteString(detailRow("forks", fmt.Sprintf("%d (%.0f%%)", forks, 100*float64(forks)/float64(len(m.items)))) + "\n")
+	}
+
+	b.WriteString("\n" + detailTitleStyle.Render(tr("Languages")) + "\n")
+	langs := m.languages()
+	for i, lang := range langs {
+		if i == 10 {
+			b.WriteString("  " + tr("… and %d more", len(langs)-10) + "\n")
+			break
+		}
+		width := lang.count * statsBarWidth / langs[0].count
+		bar := barStyle.Render(strings.Repeat(glyph("█", "#"), max(width, 1)))
+		b.WriteString(fmt.Sprintf("  %-14s %s %d\n", lang.name, bar, lang.count))
+	}
+
+	var recent []item
+	for _, it := range m.items {
+		if !it.updated.IsZero() {
+			recent = append(recent, it)
+		}
+	}
+	sort.Slice(recent, func(a, b int) bool { return recent[a].updated.After(recent[b].updated) })
+	b.WriteString("\n" + detailTitleStyle.Render(tr("Recently active")) + "\n")
+	for _, it := range recent[:min(5, len(recent))] {
+		b.WriteString(fmt.Sprintf("  %-30s %s\n", it.name, relativeTime(it.updated)))
+	}
+
+	b.WriteString("\n" + tr("esc: back"))
+	return normalStyle.Render(b.String())
+}
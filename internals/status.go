@@ -0,0 +1,118 @@
+package internals
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var statusStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("252")).
+	Background(lipgloss.Color("236")).
+	Padding(0, 1)
+
+type sortMode int
+
+const (
+	sortByName sortMode = iota
+	sortByStars
+	sortByUpdated
+)
+
+func (s sortMode) String() string {
+	switch s {
+	case sortByStars:
+		return "stars"
+	case sortByUpdated:
+		return "updated"
+	default:
+		return "name"
+	}
+}
+
+func (s sortMode) next() sortMode {
+	return (s + 1) % 3
+}
+
+func sortItems(items []list.Item, mode sortMode) {
+	sort.SliceStable(items, func(a, b int) bool {
+		x, y := items[a].(item), items[b].(item)
+		if x.pinned != y.pinned {
+			return x.pinned
+		}
+		switch mode {
+		case sortByStars:
+			return x.stars > y.stars
+		case sortByUpdated:
+			return x.updated.After(y.updated)
+		default:
+			return strings.ToLower(x.name) < strings.ToLower(y.name)
+		}
+	})
+}
+
+func (m repoModel) statusBar() string {
+	user := tr("anonymous")
+	if m.info.login != "" {
+		user = "@" + m.info.login
+	}
+	if len(m.info.scopes) > 0 {
+		user += " [" + strings.Join(m.info.scopes, ", ") + "]"
+	}
+
+	provider := activeProfile.provider()
+	if activeProfileName != "" {
+		provider += " (" + activeProfileName + ")"
+	}
+
+	parts := []string{
+		provider,
+		user,
+		tr("rate %d/%d", m.info.rate.Remaining, m.info.rate.Limit),
+		tr("%d repos", len(m.list.Items())),
+		tr("sort: %s", tr(m.sort.String())),
+	}
+	if !m.info.cachedAt.IsZero() {
+		parts = append(parts, tr("cached %s", relativeTime(m.info.cachedAt)))
+	}
+	if s := m.rateStatus(); s != "" {
+		parts = append(parts, s)
+	}
+	if m.partial {
+		parts = append(parts, tr("partial: %s", cfg.CloneFilter))
+	}
+	if m.affiliation != "" {
+		parts = append(parts, tr("affiliation: %s", m.affiliation))
+	}
+	if m.info.login != "" {
+		private, public := m.visibilityCounts()
+		counts := tr("%d private / %d public", private, public)
+		if m.filter.visibility != "" {
+			counts += " " + tr("(showing %s)", tr(m.filter.visibility))
+		}
+		parts = append(parts, counts)
+	}
+	if m.filter.topic != "" {
+		parts = append(parts, tr("topic: %s", m.filter.topic))
+	}
+	if m.filter.license != "" {
+		parts = append(parts, tr("license: %s", m.filter.license))
+	}
+	if m.filter.activity != "" {
+		parts = append(parts, activityLabel(m.filter.activity))
+	}
+	if d := refreshInterval(); m.watch && d > 0 {
+		parts = append(parts, tr("refresh: %s", d))
+	}
+	if f := m.list.FilterValue(); f != "" {
+		parts = append(parts, tr("filter: %s", f))
+	}
+
+	if m.info.tokenWarning != "" {
+		parts = append(parts, glyph("⚠ ", tr("warning: "))+m.info.tokenWarning)
+	}
+
+	return statusStyle.Width(m.width).Render(strings.Join(parts, " · "))
+}
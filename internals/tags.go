@@ -0,0 +1,125 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type tag struct {
+	name   string
+	commit string
+}
+
+func (t tag) Title() string       { return t.name }
+func (t tag) Description() string { return t.commit }
+func (t tag) FilterValue() string { return t.name }
+
+// cloneAtTagMsg tells the repository list below to clone its selection at
+// tag.
+type cloneAtTagMsg struct{ tag string }
+
+type tagsFetchedMsg struct {
+	tags []list.Item
+	err  error
+}
+
+func fetchTags(owner, repo string) tea.Cmd {
+	return func() tea.Msg {
+		if activeProfile.provider() != providerGitHub {
+			return tagsFetchedMsg{err: fmt.Errorf("listing tags is only supported for GitHub profiles")}
+		}
+		found, _, err := newAPI().ListTags(context.Background(), owner, repo)
+		if err != nil {
+			return tagsFetchedMsg{err: fmt.Errorf("failed to fetch tags: %w", err)}
+		}
+		items := make([]list.Item, len(found))
+		for i, t := range found {
+			sha := t.GetCommit().GetSHA()
+			if len(sha) > 7 {
+				sha = sha[:7]
+			}
+			items[i] = tag{name: t.GetName(), commit: sha}
+		}
+		return tagsFetchedMsg{tags: items}
+	}
+}
+
+// tagsModel picks a tag to check out instead of the default branch; the
+// clone then goes through the usual LFS and size checks.
+type tagsModel struct {
+	it      item
+	list    list.Model
+	loading bool
+	err     error
+}
+
+func newTagsModel(it item) tagsModel {
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	localizeList(&l)
+	l.Title = tr("Clone %s at tag", it.name)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", tr("clone at tag"))),
+		}
+	}
+	return tagsModel{it: it, list: l, loading: true}
+}
+
+func (m tagsModel) crumbs() []string { return []string{"tags"} }
+
+func (m tagsModel) Init() tea.Cmd {
+	return fetchTags(m.it.owner, m.it.name)
+}
+
+func (m tagsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tagsFetchedMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, m.list.SetItems(msg.tags)
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			if m.list.FilterState() == list.Unfiltered {
+				return m, goBack
+			}
+		case "enter":
+			t, ok := m.list.SelectedItem().(tag)
+			if !ok {
+				return m, nil
+			}
+			return m, backWith(cloneAtTagMsg{tag: t.name})
+		}
+	case tea.WindowSizeMsg:
+		h, v := normalStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v-1)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m tagsModel) View() string {
+	body := m.list.View()
+	switch {
+	case m.loading:
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("Loading tags..."))
+	case m.err == nil && len(m.list.Items()) == 0:
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("%s has no tags.", m.it.name))
+	}
+	if m.err != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, errorStyle.Render(m.err.Error()))
+	}
+	return normalStyle.Render(body)
+}
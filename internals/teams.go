@@ -0,0 +1,218 @@
+package internals
+
+import (
+	"context"
+	"strings"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v50/github"
+)
+
+// affiliations are cycled with A when browsing your own account; empty is
+// the normal listing.
+var affiliations = []string{"", gitls.AffiliationOwner, gitls.AffiliationCollaborator, gitls.AffiliationOrgMember}
+
+func nextAffiliation(current string) string {
+	for i, a := range affiliations {
+		if a == current {
+			return affiliations[(i+1)%len(affiliations)]
+		}
+	}
+	return ""
+}
+
+type reposFilteredMsg struct {
+	affiliation string
+	repos       []*github.Repository
+	err         error
+}
+
+func fetchAffiliated(username, affiliation string) tea.Cmd {
+	return func() tea.Msg {
+		if affiliation == "" {
+			repos, _, err := fetchRepos(username)
+			return reposFilteredMsg{repos: repos, err: err}
+		}
+		repos, err := newClient().ListOwnRepos(context.Background(), affiliation)
+		return reposFilteredMsg{affiliation: affiliation, repos: repos, err: err}
+	}
+}
+
+// ownAccount reports whether the list shows the authenticated user, the
+// only account affiliation filters apply to.
+func (m repoModel) ownAccount() bool {
+	return m.info.login != "" && strings.EqualFold(m.username, m.info.login)
+}
+
+func (m repoModel) cycleAffiliation() (tea.Model, tea.Cmd) {
+	aff := nextAffiliation(m.affiliation)
+	m.cloning = true
+	m.cloneMsg = tr("Loading repositories...")
+	return m, tea.Batch(m.spinner.Tick, fetchAffiliated(m.username, aff))
+}
+
+func (m repoModel) applyAffiliation(msg reposFilteredMsg) (tea.Model, tea.Cmd) {
+	m.cloning = false
+	m.cloneMsg = ""
+	if msg.err != nil {
+		m.cloneError = true
+		m.cloneMsg = tr("Error loading repositories: %v", msg.err)
+		return m, nil
+	}
+	m.affiliation = msg.affiliation
+	m.repos = msg.repos
+	items := make([]list.Item, len(msg.repos))
+	for i, repo := range msg.repos {
+		it := newItem(repo)
+		it.showOwner = msg.affiliation != ""
+		items[i] = it
+	}
+	m.list.Title = tr("%s's GitHub Repositories", m.username)
+	if m.affiliation != "" {
+		m.list.Title += " (" + strings.ReplaceAll(m.affiliation, "_", " ") + ")"
+	}
+	m.list.ResetSelected()
+	return m, m.setItems(items)
+}
+
+type team struct {
+	slug        string
+	name        string
+	description string
+}
+
+func (t team) Title() string       { return t.name }
+func (t team) Description() string { return t.description }
+func (t team) FilterValue() string { return t.name }
+
+type teamsFetchedMsg struct {
+	teams []list.Item
+	err   error
+}
+
+func fetchTeams(org string) tea.Cmd {
+	return func() tea.Msg {
+		teams, err := newClient().ListTeams(context.Background(), org)
+		if err != nil {
+			return teamsFetchedMsg{err: err}
+		}
+		items := make([]list.Item, len(teams))
+		for i, t := range teams {
+			items[i] = team{slug: t.GetSlug(), name: t.GetName(), description: t.GetDescription()}
+		}
+		return teamsFetchedMsg{teams: items}
+	}
+}
+
+// fetchTeamRepos builds the list of a team's repositories, showing the
+// owner of those that belong elsewhere.
+func fetchTeamRepos(org, slug string, info fetchInfo) tea.Cmd {
+	return func() tea.Msg {
+		repos, err := newClient().ListTeamRepos(context.Background(), org, slug)
+		if err != nil {
+			return pageLoadedMsg{err: err}
+		}
+		items := make([]list.Item, len(repos))
+		for i, repo := range repos {
+			it := newItem(repo)
+			it.showOwner = !strings.EqualFold(repo.GetOwner().GetLogin(), org)
+			items[i] = it
+		}
+		sortItems(items, sortByName)
+		rm := newRepoModel(tr("%s/%s repositories", org, slug), items)
+		rm.username = org
+		rm.repos = repos
+		rm.info = info
+		rm.crumb = slug
+		return pageLoadedMsg{model: rm}
+	}
+}
+
+// teamsModel lists an organization's teams; picking one shows only that
+// team's repositories.
+type teamsModel struct {
+	info    fetchInfo
+	org     string
+	list    list.Model
+	loading bool
+	opening string // team whose repositories are being fetched
+	err     error
+}
+
+func newTeamsModel(org string, info fetchInfo) teamsModel {
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	localizeList(&l)
+	l.Title = tr("%s teams", org)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", tr("show team repositories"))),
+		}
+	}
+	return teamsModel{org: org, info: info, list: l, loading: true}
+}
+
+func (m teamsModel) crumbs() []string { return []string{"teams"} }
+
+func (m teamsModel) Init() tea.Cmd {
+	return fetchTeams(m.org)
+}
+
+func (m teamsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case teamsFetchedMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, m.list.SetItems(msg.teams)
+	case pageLoadedMsg:
+		m.opening = ""
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m, navigate(msg.model)
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			if m.list.FilterState() == list.Unfiltered {
+				return m, goBack
+			}
+		case "enter":
+			t, ok := m.list.SelectedItem().(team)
+			if !ok || m.opening != "" {
+				return m, nil
+			}
+			m.opening, m.err = t.slug, nil
+			return m, fetchTeamRepos(m.org, t.slug, m.info)
+		}
+	case tea.WindowSizeMsg:
+		h, v := normalStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v-1)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m teamsModel) View() string {
+	body := m.list.View()
+	if m.loading {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("Loading teams..."))
+	}
+	if m.opening != "" {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("Loading %s...", m.opening))
+	}
+	if m.err != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, errorStyle.Render(m.err.Error()))
+	}
+	return normalStyle.Render(body)
+}
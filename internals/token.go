@@ -0,0 +1,161 @@
+package internals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+)
+
+const keyringService = "gitls"
+
+// storedTokens caches lookups, the keychain can be slow to answer.
+var storedTokens sync.Map
+
+// tokenAccount is the keychain account name for the active profile.
+func tokenAccount() string {
+	if activeProfileName == "" {
+		return "default"
+	}
+	return activeProfileName
+}
+
+func tokenFilePath() (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "tokens.json"), nil
+}
+
+func readTokenFile() (map[string]string, error) {
+	path, err := tokenFilePath()
+	if err != nil {
+		return nil, err
+	}
+	tokens := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tokens, json.Unmarshal(data, &tokens)
+}
+
+func writeTokenFile(tokens map[string]string) error {
+	path, err := tokenFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// storedToken returns the token saved by `gitls login` for the active
+// profile, looking in the OS keychain first and the token file second.
+func storedToken() string {
+	account := tokenAccount()
+	if token, ok := storedTokens.Load(account); ok {
+		return token.(string)
+	}
+
+	token, err := keyring.Get(keyringService, account)
+	if err != nil {
+		tokens, err := readTokenFile()
+		if err != nil {
+			logger.Debug("could not read token file", "err", err)
+		}
+		token = tokens[account]
+	}
+	storedTokens.Store(account, token)
+	return token
+}
+
+// saveToken stores token in the keychain, falling back to a 0600 file when
+// no keychain is available. It reports where the token ended up.
+func saveToken(token string) (string, error) {
+	account := tokenAccount()
+	storedTokens.Delete(account)
+	err := keyring.Set(keyringService, account, token)
+	if err == nil {
+		return "OS keychain", nil
+	}
+	logger.Debug("keychain unavailable, using token file", "err", err)
+
+	tokens, err := readTokenFile()
+	if err != nil {
+		return "", err
+	}
+	tokens[account] = token
+	if err := writeTokenFile(tokens); err != nil {
+		return "", err
+	}
+	path, _ := tokenFilePath()
+	return path, nil
+}
+
+func deleteToken() error {
+	account := tokenAccount()
+	storedTokens.Delete(account)
+	keyErr := keyring.Delete(keyringService, account)
+
+	tokens, err := readTokenFile()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[account]; ok {
+		delete(tokens, account)
+		return writeTokenFile(tokens)
+	}
+	if errors.Is(keyErr, keyring.ErrNotFound) {
+		return fmt.Errorf("no stored token for %s", account)
+	}
+	return nil
+}
+
+// Login validates token against the active profile's provider and stores
+// it for later sessions.
+func Login(token string) error {
+	if token == "" {
+		return errors.New("empty token")
+	}
+
+	if activeProfile.provider() == providerGitHub {
+		ctx := context.Background()
+		user, _, err := newTokenAPI(token).CurrentUser(ctx)
+		if err != nil {
+			return fmt.Errorf("token rejected: %w", err)
+		}
+		fmt.Printf("Authenticated as %s\n", user.GetLogin())
+	}
+
+	where, err := saveToken(token)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Token for %s saved to %s\n", tokenAccount(), where)
+	return nil
+}
+
+// Logout removes the stored token of the active profile.
+func Logout() error {
+	if err := deleteToken(); err != nil {
+		return err
+	}
+	fmt.Printf("Removed stored token for %s\n", tokenAccount())
+	return nil
+}
@@ -0,0 +1,149 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v50/github"
+)
+
+// trafficDays is how far back GitHub keeps traffic data.
+const trafficDays = 14
+
+var (
+	sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+	sparkPlain  = []rune(" .:-=+*#")
+)
+
+// sparkline draws one character per value, scaled to the largest.
+func sparkline(values []int) string {
+	blocks := sparkBlocks
+	if plainMode {
+		blocks = sparkPlain
+	}
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if peak > 0 {
+			i = v * (len(blocks) - 1) / peak
+		}
+		b.WriteRune(blocks[i])
+	}
+	return b.String()
+}
+
+// trafficSeries is a daily count for the last trafficDays days.
+type trafficSeries struct {
+	daily   []int
+	total   int
+	uniques int
+}
+
+// dailyCounts spreads data over the last trafficDays days ending today;
+// GitHub leaves days without traffic out.
+func dailyCounts(data []*github.TrafficData, today time.Time) []int {
+	counts := make([]int, trafficDays)
+	end := today.UTC().Truncate(24 * time.Hour)
+	for _, d := range data {
+		day := d.GetTimestamp().UTC().Truncate(24 * time.Hour)
+		i := trafficDays - 1 - int(end.Sub(day).Hours()/24)
+		if i >= 0 && i < trafficDays {
+			counts[i] += d.GetCount()
+		}
+	}
+	return counts
+}
+
+type trafficFetchedMsg struct {
+	views  trafficSeries
+	clones trafficSeries
+	err    error
+}
+
+func fetchTraffic(owner, repo string) tea.Cmd {
+	return func() tea.Msg {
+		if activeProfile.provider() != providerGitHub {
+			return trafficFetchedMsg{err: fmt.Errorf("traffic statistics are only supported for GitHub profiles")}
+		}
+		ctx := context.Background()
+		api := newAPI()
+		views, _, err := api.TrafficViews(ctx, owner, repo)
+		if err != nil {
+			return trafficFetchedMsg{err: fmt.Errorf("failed to fetch traffic (push access is required): %w", err)}
+		}
+		clones, _, err := api.TrafficClones(ctx, owner, repo)
+		if err != nil {
+			return trafficFetchedMsg{err: fmt.Errorf("failed to fetch clones: %w", err)}
+		}
+		now := time.Now()
+		return trafficFetchedMsg{
+			views:  trafficSeries{daily: dailyCounts(views.Views, now), total: views.GetCount(), uniques: views.GetUniques()},
+			clones: trafficSeries{daily: dailyCounts(clones.Clones, now), total: clones.GetCount(), uniques: clones.GetUniques()},
+		}
+	}
+}
+
+// trafficModel shows views and clones of a repository over the last two
+// weeks.
+type trafficModel struct {
+	it      item
+	views   trafficSeries
+	clones  trafficSeries
+	loading bool
+	err     error
+}
+
+func newTrafficModel(it item) trafficModel {
+	return trafficModel{it: it, loading: true}
+}
+
+func (m trafficModel) crumbs() []string { return []string{"traffic"} }
+
+func (m trafficModel) Init() tea.Cmd {
+	return fetchTraffic(m.it.owner, m.it.name)
+}
+
+func (m trafficModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case trafficFetchedMsg:
+		m.loading = false
+		m.views, m.clones, m.err = msg.views, msg.clones, msg.err
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q", "V":
+			return m, goBack
+		}
+	}
+	return m, nil
+}
+
+func trafficRow(label string, s trafficSeries) string {
+	return detailRow(label, tr("%s  %d total, %d unique", barStyle.Render(sparkline(s.daily)), s.total, s.uniques))
+}
+
+func (m trafficModel) View() string {
+	var b strings.Builder
+	b.WriteString(statsTitleStyle.Render(tr("Traffic for %s/%s, last %d days", m.it.owner, m.it.name, trafficDays)) + "\n")
+
+	switch {
+	case m.loading:
+		b.WriteString(tr("Loading traffic...") + "\n")
+	case m.err != nil:
+		b.WriteString(errorStyle.Render(m.err.Error()) + "\n")
+	default:
+		b.WriteString(trafficRow(tr("views"), m.views) + "\n")
+		b.WriteString(trafficRow(tr("clones"), m.clones) + "\n")
+	}
+
+	b.WriteString("\n" + tr("esc: back"))
+	return normalStyle.Render(b.String())
+}
@@ -0,0 +1,264 @@
+package internals
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+)
+
+// trashEntry records where a trashed directory came from. Each entry is a
+// directory <id> in the trash with its record next to it in <id>.json.
+type trashEntry struct {
+	ID   string    `json:"id"`
+	Path string    `json:"path"` // where it was, absolute
+	Time time.Time `json:"time"`
+}
+
+func trashDir() (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "trash"), nil
+}
+
+// moveToTrash moves dir into the trash instead of deleting it, returning
+// the id to restore it by.
+func moveToTrash(dir string) (string, error) {
+	dir = absCloneDir(dir)
+	trash, err := trashDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(trash, 0o755); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	id := now.Format("20060102-150405") + "-" + filepath.Base(dir)
+	for n := 2; ; n++ {
+		if _, err := os.Lstat(filepath.Join(trash, id)); errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		id = fmt.Sprintf("%s-%s-%d", now.Format("20060102-150405"), filepath.Base(dir), n)
+	}
+
+	entry := trashEntry{ID: id, Path: dir, Time: now.UTC()}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(trash, id+".json"), data, 0o644); err != nil {
+		return "", err
+	}
+	if err := moveDir(dir, filepath.Join(trash, id)); err != nil {
+		os.Remove(filepath.Join(trash, id+".json"))
+		return "", err
+	}
+	logger.Debug("moved to trash", "dir", dir, "id", id)
+	return id, nil
+}
+
+// moveDir renames src to dst, copying and then deleting when they are on
+// different filesystems.
+func moveDir(src, dst string) error {
+	err := os.Rename(src, dst)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	if err := copyTree(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0o700)
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target, info.Mode().Perm())
+		}
+	})
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// readTrash lists the trash, oldest first.
+func readTrash() ([]trashEntry, error) {
+	trash, err := trashDir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(trash, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var entries []trashEntry
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var e trashEntry
+		if err := json.Unmarshal(data, &e); err != nil {
+			logger.Warn("skipping broken trash record", "file", f, "err", err)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].Time.Before(entries[b].Time) })
+	return entries, nil
+}
+
+// findTrash picks the entries matching what, an id or an original path; an
+// empty what matches everything.
+func findTrash(entries []trashEntry, what string) []trashEntry {
+	if what == "" {
+		return entries
+	}
+	path := absCloneDir(what)
+	var found []trashEntry
+	for _, e := range entries {
+		if e.ID == what || e.Path == path {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// PrintTrash lists what is in the trash.
+func PrintTrash() error {
+	entries, err := readTrash()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("the trash is empty")
+		return nil
+	}
+	trash, _ := trashDir()
+	for _, e := range entries {
+		size := humanBytes(gitDirSize(filepath.Join(trash, e.ID), true))
+		fmt.Printf("%s  %-40s  %8s  %s\n", e.Time.Local().Format("2006-01-02 15:04"), e.ID, size, e.Path)
+	}
+	return nil
+}
+
+// TrashRestore moves a trashed directory back, to where it was or into
+// to. what is an id or the original path; when a path was trashed several
+// times the newest copy comes back.
+func TrashRestore(what, to string) error {
+	entries, err := readTrash()
+	if err != nil {
+		return err
+	}
+	found := findTrash(entries, what)
+	if len(found) == 0 {
+		return fmt.Errorf("nothing in the trash matches %s (see gitls trash list)", what)
+	}
+	e := found[len(found)-1]
+
+	dest := e.Path
+	if to != "" {
+		dest = absCloneDir(to)
+	}
+	if _, err := os.Lstat(dest); err == nil {
+		return fmt.Errorf("%s already exists; restore elsewhere with --to", dest)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+	if err := untrash(e.ID, dest); err != nil {
+		return err
+	}
+	fmt.Printf("restored %s\n", dest)
+	return nil
+}
+
+// untrash moves the trashed directory id to dest and forgets it.
+func untrash(id, dest string) error {
+	trash, err := trashDir()
+	if err != nil {
+		return err
+	}
+	if err := moveDir(filepath.Join(trash, id), dest); err != nil {
+		return err
+	}
+	return os.Remove(filepath.Join(trash, id+".json"))
+}
+
+// TrashEmpty deletes trashed directories for good: all of them, the ones
+// matching what, or the ones trashed longer ago than olderThan.
+func TrashEmpty(what string, olderThan time.Duration) error {
+	entries, err := readTrash()
+	if err != nil {
+		return err
+	}
+	trash, err := trashDir()
+	if err != nil {
+		return err
+	}
+	removed := 0
+	for _, e := range findTrash(entries, what) {
+		if olderThan > 0 && time.Since(e.Time) < olderThan {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(trash, e.ID)); err != nil {
+			return err
+		}
+		if err := os.Remove(filepath.Join(trash, e.ID+".json")); err != nil {
+			return err
+		}
+		removed++
+	}
+	if what != "" && removed == 0 && olderThan == 0 {
+		return fmt.Errorf("nothing in the trash matches %s (see gitls trash list)", what)
+	}
+	fmt.Printf("deleted %d from the trash\n", removed)
+	return nil
+}
@@ -0,0 +1,184 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v50/github"
+)
+
+// trendingPeriods approximate GitHub's trending page: the most starred
+// repositories created within the period.
+var trendingPeriods = []struct {
+	name string
+	days int
+}{
+	{"day", 1},
+	{"week", 7},
+	{"month", 30},
+}
+
+func trendingPeriod(name string) (int, error) {
+	for _, p := range trendingPeriods {
+		if p.name == name {
+			return p.days, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown period %q (want day, week or month)", name)
+}
+
+func trendingQuery(language string, days int, now time.Time) string {
+	q := "created:>" + now.AddDate(0, 0, -days).Format("2006-01-02")
+	if language != "" {
+		q += " language:" + strings.ReplaceAll(language, " ", "-")
+	}
+	return q
+}
+
+func fetchTrending(language string, days int) ([]*github.Repository, error) {
+	if activeProfile.provider() != providerGitHub {
+		return nil, fmt.Errorf("trending is only supported for GitHub profiles")
+	}
+	ctx := context.Background()
+	opts := &github.SearchOptions{Sort: "stars", Order: "desc", ListOptions: github.ListOptions{PerPage: 100}}
+	res, _, err := newAPI().SearchRepos(ctx, trendingQuery(language, days, time.Now()), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search trending repositories: %w", err)
+	}
+	return res.Repositories, nil
+}
+
+func trendingTitle(language, period string) string {
+	if language != "" {
+		return tr("Trending this %s in %s", tr(period), language)
+	}
+	return tr("Trending this %s", tr(period))
+}
+
+// trendingList puts the results in a normal repository list, keeping the
+// search's star order.
+func trendingList(repos []*github.Repository, title string) repoModel {
+	items := make([]list.Item, len(repos))
+	for i, repo := range repos {
+		it := newItem(repo)
+		it.showOwner = true
+		items[i] = it
+	}
+	m := newRepoModel(title, items)
+	m.repos = repos
+	m.sort = sortByStars
+	return m
+}
+
+// trendingModel asks for a language and period before searching.
+type trendingModel struct {
+	input     textinput.Model
+	period    int // index into trendingPeriods
+	searching bool
+	err       error
+	width     int
+	height    int
+}
+
+func newTrendingModel() trendingModel {
+	ti := textinput.New()
+	ti.Prompt = tr("language: ")
+	ti.Placeholder = tr("any")
+	ti.CharLimit = 40
+	ti.Focus()
+	return trendingModel{input: ti, period: 1}
+}
+
+func (m trendingModel) crumbs() []string { return []string{"trending"} }
+
+func (m trendingModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m trendingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case pageLoadedMsg:
+		m.searching = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m, navigate(msg.model)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m, goBack
+		case "tab":
+			m.period = (m.period + 1) % len(trendingPeriods)
+			return m, nil
+		case "enter":
+			if m.searching {
+				return m, nil
+			}
+			language := strings.TrimSpace(m.input.Value())
+			period := trendingPeriods[m.period]
+			m.searching, m.err = true, nil
+			return m, func() tea.Msg {
+				repos, err := fetchTrending(language, period.days)
+				if err != nil {
+					return pageLoadedMsg{err: err}
+				}
+				return pageLoadedMsg{model: trendingList(repos, trendingTitle(language, period.name))}
+			}
+		}
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m trendingModel) View() string {
+	var b strings.Builder
+	b.WriteString(tr("Trending repositories") + "\n\n")
+	b.WriteString(m.input.View() + "\n")
+	b.WriteString(tr("period:") + "   ")
+	for i, p := range trendingPeriods {
+		if i == m.period {
+			b.WriteString(cursorStyle.Render("["+tr(p.name)+"]") + " ")
+		} else {
+			b.WriteString(" " + tr(p.name) + "  ")
+		}
+	}
+	if m.searching {
+		b.WriteString("\n\n" + tr("Searching..."))
+	}
+	if m.err != nil {
+		b.WriteString("\n\n" + errorStyle.Render(m.err.Error()))
+	}
+	b.WriteString("\n\n" + tr("enter: search · tab: change period · esc: back"))
+	return normalStyle.Render(b.String())
+}
+
+// TrendingRun opens the trending list directly, or the language and period
+// prompt when period is empty.
+func TrendingRun(language, period string) error {
+	if period == "" {
+		runProgram(newTrendingModel())
+		return nil
+	}
+	days, err := trendingPeriod(period)
+	if err != nil {
+		return err
+	}
+	repos, err := fetchTrending(language, days)
+	if err != nil {
+		return err
+	}
+	runProgram(trendingList(repos, trendingTitle(language, period)))
+	return nil
+}
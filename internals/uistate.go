@@ -0,0 +1,107 @@
+package internals
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const uiStateFile = "ui.json"
+
+// uiState is where the last session left off, so the next one can start
+// there.
+type uiState struct {
+	Profile    string `json:"profile,omitempty"`
+	User       string `json:"user"`
+	Selected   string `json:"selected,omitempty"` // owner/name
+	Sort       string `json:"sort,omitempty"`
+	Visibility string `json:"visibility,omitempty"`
+	Topic      string `json:"topic,omitempty"`
+	License    string `json:"license,omitempty"`
+	Activity   string `json:"activity,omitempty"`
+	Query      string `json:"query,omitempty"` // the list's filter text
+}
+
+// loadUIState returns the last session's state if it was saved under the
+// active profile.
+func loadUIState() (uiState, bool) {
+	var s uiState
+	if err := readStateFile(uiStateFile, &s); err != nil {
+		logger.Warn("could not read UI state", "err", err)
+		return s, false
+	}
+	return s, s.User != "" && s.Profile == activeProfileName
+}
+
+// saveUIState records the account list nearest the top of the final
+// screen stack.
+func saveUIState(final tea.Model) {
+	nav, ok := final.(navModel)
+	if !ok {
+		return
+	}
+	for i := len(nav.stack) - 1; i >= 0; i-- {
+		m, ok := nav.stack[i].(repoModel)
+		if !ok || m.username == "" || m.crumb != "" {
+			continue
+		}
+		if err := writeStateFile(uiStateFile, m.uiState()); err != nil {
+			logger.Warn("could not save UI state", "err", err)
+		}
+		return
+	}
+}
+
+func (m repoModel) uiState() uiState {
+	s := uiState{
+		Profile:    activeProfileName,
+		User:       m.username,
+		Sort:       m.sort.String(),
+		Visibility: m.filter.visibility,
+		Topic:      m.filter.topic,
+		License:    m.filter.license,
+		Activity:   m.filter.activity,
+		Query:      m.list.FilterValue(),
+	}
+	if it, ok := m.list.SelectedItem().(item); ok {
+		s.Selected = it.owner + "/" + it.name
+	}
+	return s
+}
+
+// withUIState arranges for the last session's cursor, sort and filters to
+// come back when model lists the same account.
+func withUIState(model tea.Model) tea.Model {
+	m, ok := model.(repoModel)
+	if !ok {
+		return model
+	}
+	if s, ok := loadUIState(); ok && strings.EqualFold(s.User, m.username) {
+		m.restore = &s
+	}
+	return m
+}
+
+// applyUIState restores a saved state once the list has its size, so the
+// cursor lands on the right page.
+func (m *repoModel) applyUIState() tea.Cmd {
+	s := m.restore
+	m.restore = nil
+	for mode := sortByName; mode <= sortByUpdated; mode++ {
+		if mode.String() == s.Sort {
+			m.sort = mode
+		}
+	}
+	m.filter = repoFilter{visibility: s.Visibility, topic: s.Topic, license: s.License, activity: s.Activity}
+	cmd := m.setItems(m.allItems())
+	if s.Query != "" {
+		m.list.SetFilterText(s.Query)
+	}
+	for i, li := range m.list.VisibleItems() {
+		if it, ok := li.(item); ok && strings.EqualFold(it.owner+"/"+it.name, s.Selected) {
+			m.list.Select(i)
+			break
+		}
+	}
+	return tea.Batch(cmd, m.selectionChanged())
+}
@@ -0,0 +1,50 @@
+package internals
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type undoFinishedMsg struct {
+	dir string
+	id  string // in the trash
+	err error
+}
+
+func removeClone(dir string) tea.Cmd {
+	return func() tea.Msg {
+		logger.Debug("removing clone", "dir", dir)
+		id, err := moveToTrash(dir)
+		return undoFinishedMsg{dir: dir, id: id, err: err}
+	}
+}
+
+// absCloneDir resolves a clone directory so undo and history don't depend
+// on the working directory staying the same.
+func absCloneDir(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	return abs
+}
+
+func (m repoModel) updateUndo(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		m.confirmUndo = false
+		dir := m.lastClone
+		m.lastClone = ""
+		return m, removeClone(dir)
+	case "n", "esc":
+		m.confirmUndo = false
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m repoModel) undoView() string {
+	return errorStyle.Render(tr("Move %s to the trash? y: move · n: keep", m.lastClone))
+}
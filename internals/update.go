@@ -0,0 +1,248 @@
+package internals
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	"github.com/google/go-github/v50/github"
+)
+
+// gitls' own repository, where releases are published.
+const (
+	releaseOwner = "arshpsps"
+	releaseRepo  = "gitls"
+	checksumFile = "checksums.txt"
+)
+
+// releasesAPI talks to github.com even when the active profile is GitLab or
+// GitHub Enterprise.
+func releasesAPI() gitls.API {
+	if activeProfile.provider() == providerGitHub && activeProfile.BaseURL == "" {
+		return newAPI()
+	}
+	return gitls.New(gitls.Options{
+		HTTPClient: &http.Client{Transport: loggingTransport{next: http.DefaultTransport}},
+		Logger:     logger,
+	}).API()
+}
+
+// parseVersion reads "v1.2.3" or "1.2.3"; ok is false for development
+// builds and anything else that isn't a release version.
+func parseVersion(v string) ([]int, bool) {
+	v, _, _ = strings.Cut(strings.TrimPrefix(v, "v"), "-")
+	fields := strings.Split(v, ".")
+	nums := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, false
+		}
+		nums[i] = n
+	}
+	return nums, true
+}
+
+// newerVersion reports whether latest is a later release than current.
+func newerVersion(current, latest string) bool {
+	cur, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	lat, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	for i := 0; i < max(len(cur), len(lat)); i++ {
+		var c, l int
+		if i < len(cur) {
+			c = cur[i]
+		}
+		if i < len(lat) {
+			l = lat[i]
+		}
+		if c != l {
+			return l > c
+		}
+	}
+	return false
+}
+
+func latestRelease() (*github.RepositoryRelease, error) {
+	release, _, err := releasesAPI().LatestRelease(context.Background(), releaseOwner, releaseRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the latest release: %w", err)
+	}
+	return release, nil
+}
+
+// CheckUpdate prints whether a newer release than version is available.
+func CheckUpdate(version string) error {
+	release, err := latestRelease()
+	if err != nil {
+		return err
+	}
+	latest := release.GetTagName()
+	switch {
+	case newerVersion(version, latest):
+		fmt.Printf("gitls %s is available (you have %s); run gitls self-update\n", latest, version)
+	case !isReleaseVersion(version):
+		fmt.Printf("this is a development build; the latest release is %s\n", latest)
+	default:
+		fmt.Printf("gitls %s is the latest release\n", version)
+	}
+	return nil
+}
+
+func isReleaseVersion(v string) bool {
+	_, ok := parseVersion(v)
+	return ok
+}
+
+// assetName is the release binary for this platform, e.g.
+// gitls_linux_amd64 or gitls_windows_amd64.exe.
+func assetName() string {
+	name := fmt.Sprintf("gitls_%s_%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+func findAsset(release *github.RepositoryRelease, name string) *github.ReleaseAsset {
+	for _, asset := range release.Assets {
+		if asset.GetName() == name {
+			return asset
+		}
+	}
+	return nil
+}
+
+func download(url string) ([]byte, error) {
+	client := &http.Client{Transport: loggingTransport{next: http.DefaultTransport}}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// expectedChecksum finds name in a sha256sum style checksums file.
+func expectedChecksum(sums []byte, name string) (string, error) {
+	sc := bufio.NewScanner(strings.NewReader(string(sums)))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s has no checksum for %s", checksumFile, name)
+}
+
+// replaceExecutable swaps the running binary for data. The new file is
+// written next to it first so the rename stays on one filesystem.
+func replaceExecutable(data []byte) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".gitls-update-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return "", err
+	}
+	// Windows can't replace a running executable, but it can rename it.
+	if runtime.GOOS == "windows" {
+		old := exe + ".old"
+		os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return "", err
+		}
+		if err := os.Rename(tmp.Name(), exe); err != nil {
+			os.Rename(old, exe) // don't leave the user without gitls
+			return "", err
+		}
+		return exe, nil
+	}
+	return exe, os.Rename(tmp.Name(), exe)
+}
+
+// SelfUpdate replaces the running binary with the latest release after
+// checking it against the release's checksums. Development builds are only
+// replaced with force.
+func SelfUpdate(version string, force bool) error {
+	release, err := latestRelease()
+	if err != nil {
+		return err
+	}
+	latest := release.GetTagName()
+	if !isReleaseVersion(version) && !force {
+		return fmt.Errorf("this is a development build; pass --force to replace it with %s", latest)
+	}
+	if isReleaseVersion(version) && !newerVersion(version, latest) && !force {
+		fmt.Printf("gitls %s is the latest release\n", version)
+		return nil
+	}
+
+	name := assetName()
+	asset := findAsset(release, name)
+	if asset == nil {
+		return fmt.Errorf("release %s has no binary for %s/%s", latest, runtime.GOOS, runtime.GOARCH)
+	}
+	sumsAsset := findAsset(release, checksumFile)
+	if sumsAsset == nil {
+		return fmt.Errorf("release %s has no %s", latest, checksumFile)
+	}
+	sums, err := download(sumsAsset.GetBrowserDownloadURL())
+	if err != nil {
+		return err
+	}
+	want, err := expectedChecksum(sums, name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("downloading %s %s...\n", name, latest)
+	data, err := download(asset.GetBrowserDownloadURL())
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, want)
+	}
+
+	exe, err := replaceExecutable(data)
+	if err != nil {
+		return fmt.Errorf("failed to replace the gitls binary: %w", err)
+	}
+	fmt.Printf("updated %s to %s\n", exe, latest)
+	return nil
+}
@@ -0,0 +1,68 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type upstreamAddedMsg struct {
+	dir    string
+	parent string // owner/name
+	err    error
+}
+
+// addUpstream adds the parent of the fork cloned into dir as its upstream
+// remote, using the same protocol as the clone itself.
+func addUpstream(repo, dir string) tea.Cmd {
+	return func() tea.Msg {
+		owner, name, _ := strings.Cut(repo, "/")
+		fork, _, err := newAPI().GetRepo(context.Background(), owner, name)
+		if err != nil {
+			return upstreamAddedMsg{dir: dir, err: fmt.Errorf("failed to look up the parent of %s: %w", repo, err)}
+		}
+		parent := fork.GetParent()
+		if parent == nil {
+			return upstreamAddedMsg{dir: dir, err: fmt.Errorf("%s has no parent repository", repo)}
+		}
+		msg := upstreamAddedMsg{dir: dir, parent: parent.GetFullName()}
+		_, msg.err = runGit("-C", dir, "remote", "add", "upstream", rewriteURL(cloneURL(parent)))
+		return msg
+	}
+}
+
+// offerUpstream follows a successful clone of a fork: depending on
+// fork_upstream it adds the upstream remote, asks first or does nothing.
+func (m repoModel) offerUpstream(msg cloneFinishedMsg) (tea.Model, tea.Cmd) {
+	if activeProfile.provider() != providerGitHub {
+		return m, nil
+	}
+	switch cfg.ForkUpstream {
+	case "never":
+		return m, nil
+	case "always":
+		return m, addUpstream(msg.repo, msg.dir)
+	}
+	m.upstreamFor = &msg
+	return m, nil
+}
+
+func (m repoModel) updateUpstream(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		clone := m.upstreamFor
+		m.upstreamFor = nil
+		return m, addUpstream(clone.repo, clone.dir)
+	case "n", "esc":
+		m.upstreamFor = nil
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m repoModel) upstreamView() string {
+	return errorStyle.Render(tr("%s is a fork. Add its parent as the upstream remote? y: add · n: skip", m.upstreamFor.repo))
+}
@@ -0,0 +1,131 @@
+package internals
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/arshpsps/gitls/pkg/gitls"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v50/github"
+)
+
+const (
+	maxUserSuggestions = 5
+	// suggestPrefixLen is how much of a mistyped login is searched for;
+	// typos are rarer at the start of a name than at its end.
+	suggestPrefixLen = 3
+)
+
+type userCheckedMsg struct {
+	username    string
+	notFound    bool
+	suggestions []string
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+// suggestUsers searches for logins starting like username and returns the
+// closest ones.
+func suggestUsers(api gitls.API, username string) []string {
+	prefix := username
+	if len([]rune(prefix)) > suggestPrefixLen {
+		prefix = string([]rune(prefix)[:suggestPrefixLen])
+	}
+	opts := &github.SearchOptions{Sort: "followers", ListOptions: github.ListOptions{PerPage: 50}}
+	result, _, err := api.SearchUsers(context.Background(), prefix+" in:login", opts)
+	if err != nil {
+		logger.Debug("user search failed", "query", prefix, "err", err)
+		return nil
+	}
+	lower := strings.ToLower(username)
+	var logins []string
+	for _, u := range result.Users {
+		logins = append(logins, u.GetLogin())
+	}
+	sort.SliceStable(logins, func(a, b int) bool {
+		return editDistance(lower, strings.ToLower(logins[a])) < editDistance(lower, strings.ToLower(logins[b]))
+	})
+	return logins[:min(maxUserSuggestions, len(logins))]
+}
+
+// updateSuggestions steps through the suggested logins with up and down,
+// putting each into the input.
+func (m usernameModel) updateSuggestions(msg tea.KeyMsg) (usernameModel, bool) {
+	if len(m.suggestions) == 0 {
+		return m, false
+	}
+	switch msg.Type {
+	case tea.KeyDown:
+		m.suggestion = (m.suggestion + 1) % (len(m.suggestions) + 1)
+	case tea.KeyUp:
+		m.suggestion = (m.suggestion + len(m.suggestions)) % (len(m.suggestions) + 1)
+	default:
+		return m, false
+	}
+	// 0 is what was typed, 1..n the suggestions
+	if m.suggestion == 0 {
+		m.textInput.SetValue(m.notFound)
+	} else {
+		m.textInput.SetValue(m.suggestions[m.suggestion-1])
+	}
+	m.textInput.CursorEnd()
+	return m, true
+}
+
+func (m usernameModel) suggestionsView() string {
+	if m.notFound == "" {
+		return ""
+	}
+	line := errorStyle.Render(tr("User %s not found.", m.notFound))
+	if len(m.suggestions) == 0 {
+		return line
+	}
+	names := make([]string, len(m.suggestions))
+	for i, s := range m.suggestions {
+		names[i] = s
+		if i == m.suggestion-1 {
+			names[i] = detailTitleStyle.Render(s)
+		}
+	}
+	return line + " Did you mean " + strings.Join(names, ", ") + "? (↑/↓ to pick)"
+}
+
+// checkUser looks username up before its list is opened. Only a definite
+// 404 stops it; other errors are left to the list, which explains them.
+func checkUser(username string) tea.Cmd {
+	return func() tea.Msg {
+		msg := userCheckedMsg{username: username}
+		if activeProfile.provider() != providerGitHub {
+			return msg
+		}
+		api := newAPI()
+		_, _, err := api.GetUser(context.Background(), username)
+		if err == nil || !isNotFound(err) {
+			return msg
+		}
+		msg.notFound = true
+		msg.suggestions = suggestUsers(api, username)
+		return msg
+	}
+}
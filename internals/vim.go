@@ -0,0 +1,133 @@
+package internals
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// vimState holds the extra input state of the vim keymap preset.
+type vimState struct {
+	enabled  bool
+	pendingG bool
+	active   bool // command line open
+	input    textinput.Model
+	err      string
+}
+
+func newVimState() vimState {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	ti.CharLimit = 128
+	return vimState{enabled: cfg.Keymap == "vim", input: ti}
+}
+
+// applyVimKeys makes "g" wait for a second "g" instead of jumping to the
+// top straight away.
+func applyVimKeys(l *list.Model) {
+	l.KeyMap.GoToStart = key.NewBinding(key.WithKeys("home"), key.WithHelp("gg/home", tr("go to start")))
+}
+
+// updateVim handles vim keys, reporting whether msg was consumed.
+func (m *repoModel) updateVim(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.vim.active {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.vim.active = false
+			m.vim.input.Blur()
+			return *m, nil, true
+		case tea.KeyEnter:
+			m.vim.active = false
+			m.vim.input.Blur()
+			line := strings.TrimSpace(m.vim.input.Value())
+			m.vim.input.SetValue("")
+			model, cmd := m.runCommand(line)
+			return model, cmd, true
+		}
+		var cmd tea.Cmd
+		m.vim.input, cmd = m.vim.input.Update(msg)
+		return *m, cmd, true
+	}
+
+	if m.list.FilterState() == list.Filtering {
+		return *m, nil, false
+	}
+
+	switch msg.String() {
+	case ":":
+		m.vim.active = true
+		m.vim.err = ""
+		return *m, m.vim.input.Focus(), true
+	case "g":
+		if m.vim.pendingG {
+			m.vim.pendingG = false
+			m.list.Select(0)
+		} else {
+			m.vim.pendingG = true
+		}
+		return *m, nil, true
+	}
+	m.vim.pendingG = false
+	return *m, nil, false
+}
+
+func (m repoModel) runCommand(line string) (tea.Model, tea.Cmd) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return m, nil
+	}
+
+	switch fields[0] {
+	case "q", "quit":
+		return m, tea.Quit
+	case "clone":
+		return m.cloneSelected()
+	case "user":
+		if len(fields) != 2 {
+			m.vim.err = tr("usage: :user <name>")
+			return m, nil
+		}
+		return m, navigate(lookupUsernameModel(fields[1]))
+	case "sort":
+		if len(fields) != 2 {
+			m.vim.err = tr("usage: :sort name|stars|updated")
+			return m, nil
+		}
+		for mode := sortByName; mode <= sortByUpdated; mode++ {
+			if mode.String() == fields[1] {
+				return m, m.setSort(mode)
+			}
+		}
+		m.vim.err = tr("unknown sort %q", fields[1])
+		return m, nil
+	case "profile":
+		if len(fields) != 2 {
+			return m, navigate(newProfileModel(m.username))
+		}
+		next, err := switchProfile(fields[1], m.username)
+		if err != nil {
+			m.vim.err = err.Error()
+			return m, nil
+		}
+		return next, nil
+	case "partial":
+		m.partial = !m.partial
+		return m, nil
+	}
+
+	m.vim.err = tr("unknown command %q", fields[0])
+	return m, nil
+}
+
+func (m repoModel) vimView() string {
+	if m.vim.active {
+		return m.vim.input.View()
+	}
+	if m.vim.err != "" {
+		return errorStyle.Render(m.vim.err)
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package internals
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v50/github"
+)
+
+// watchInterval overrides refresh_interval from the config when set by
+// --watch.
+var watchInterval time.Duration
+
+// SetRefreshInterval turns on periodic refreshing of the repository list.
+func SetRefreshInterval(d time.Duration) {
+	watchInterval = d
+}
+
+func refreshInterval() time.Duration {
+	if watchInterval > 0 {
+		return watchInterval
+	}
+	if cfg.RefreshInterval == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(cfg.RefreshInterval)
+	if err != nil {
+		logger.Warn("invalid refresh_interval", "value", cfg.RefreshInterval, "err", err)
+		return 0
+	}
+	return d
+}
+
+// freshness marks repositories that changed in the last refresh.
+type freshness int
+
+const (
+	freshNone freshness = iota
+	freshNew
+	freshPushed
+)
+
+type refreshTickMsg struct{}
+
+type reposRefreshedMsg struct {
+	repos []*github.Repository
+	info  fetchInfo
+	err   error
+}
+
+func (m repoModel) refreshRepos() tea.Cmd {
+	username, affiliation, info := m.username, m.affiliation, m.info
+	return func() tea.Msg {
+		if affiliation != "" {
+			repos, err := newClient().ListOwnRepos(context.Background(), affiliation)
+			return reposRefreshedMsg{repos: repos, info: info, err: err}
+		}
+		repos, info, err := fetchRepos(username)
+		return reposRefreshedMsg{repos: repos, info: info, err: err}
+	}
+}
+
+// watchStalled reports whether the refresh cycle needs (re)starting. Ticks
+// and results are dropped while another screen is in front, so a cycle
+// that is well past due has been lost rather than being slow.
+func (m repoModel) watchStalled() bool {
+	if !m.watch || refreshInterval() <= 0 {
+		return false
+	}
+	return m.nextRefresh.IsZero() || time.Since(m.nextRefresh) > time.Minute
+}
+
+// scheduleRefresh queues the next refresh, no earlier than the rate limit
+// reset when it is used up.
+func (m *repoModel) scheduleRefresh() tea.Cmd {
+	d := refreshInterval()
+	if d <= 0 {
+		return nil
+	}
+	if reset, limited := rateLimited(); limited {
+		d = max(d, time.Until(reset))
+	}
+	m.nextRefresh = time.Now().Add(d)
+	return tea.Tick(d, func(time.Time) tea.Msg { return refreshTickMsg{} })
+}
+
+// applyRefresh swaps in the refetched repositories, keeping the selection
+// and marking repositories that are new or were pushed to since the last
+// refresh.
+func (m repoModel) applyRefresh(msg reposRefreshedMsg) (repoModel, tea.Cmd) {
+	if msg.err != nil {
+		logger.Warn("refresh failed", "user", m.username, "err", msg.err)
+		return m, m.scheduleRefresh()
+	}
+
+	old := make(map[string]item)
+	for _, li := range m.allItems() {
+		if it, ok := li.(item); ok {
+			old[it.name] = it
+		}
+	}
+	selected, _ := m.list.SelectedItem().(item)
+
+	items := make([]list.Item, len(msg.repos))
+	for i, repo := range msg.repos {
+		it := newItem(repo)
+		it.showOwner = m.affiliation != ""
+		if prev, ok := old[it.name]; !ok {
+			it.fresh = freshNew
+		} else if it.pushed.After(prev.pushed) {
+			it.fresh = freshPushed
+		}
+		items[i] = it
+	}
+
+	m.repos = msg.repos
+	m.info = msg.info
+	cmd := m.setItems(items)
+	m.list.Select(0)
+	for i, li := range m.list.Items() {
+		if li.(item).name == selected.name {
+			m.list.Select(i)
+		}
+	}
+	return m, tea.Batch(cmd, m.scheduleRefresh())
+}
@@ -0,0 +1,266 @@
+package internals
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v50/github"
+)
+
+type webhook struct {
+	id     int64
+	url    string
+	events []string
+	active bool
+	status string // last delivery, e.g. "200 OK"
+}
+
+func (h webhook) Title() string {
+	if !h.active {
+		return h.url + " " + badgeStyle.Render(tr("inactive"))
+	}
+	return h.url
+}
+
+func (h webhook) Description() string {
+	desc := strings.Join(h.events, ", ")
+	if h.status != "" {
+		desc += " · last delivery " + h.status
+	}
+	return desc
+}
+
+func (h webhook) FilterValue() string { return h.url }
+
+func newWebhook(h *github.Hook) webhook {
+	wh := webhook{id: h.GetID(), events: h.Events, active: h.GetActive()}
+	if u, ok := h.Config["url"].(string); ok {
+		wh.url = u
+	}
+	if code, ok := h.LastResponse["code"].(float64); ok {
+		wh.status = fmt.Sprint(int(code))
+		if msg, ok := h.LastResponse["message"].(string); ok && msg != "" {
+			wh.status += " " + msg
+		}
+	}
+	return wh
+}
+
+// parseHookSpec reads "URL [event,event...]"; events default to push.
+func parseHookSpec(spec string) (string, []string, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 || len(fields) > 2 {
+		return "", nil, fmt.Errorf("expected a URL optionally followed by comma separated events")
+	}
+	u, err := url.Parse(fields[0])
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", nil, fmt.Errorf("%q is not an http(s) URL", fields[0])
+	}
+	events := []string{"push"}
+	if len(fields) == 2 {
+		events = strings.Split(fields[1], ",")
+	}
+	return fields[0], events, nil
+}
+
+type hooksFetchedMsg struct {
+	hooks []list.Item
+	err   error
+}
+
+type hookDoneMsg struct {
+	status string
+	err    error
+}
+
+func fetchHooks(owner, repo string) tea.Cmd {
+	return func() tea.Msg {
+		if activeProfile.provider() != providerGitHub {
+			return hooksFetchedMsg{err: fmt.Errorf("webhooks are only supported for GitHub profiles")}
+		}
+		hooks, _, err := newAPI().ListHooks(context.Background(), owner, repo)
+		if err != nil {
+			return hooksFetchedMsg{err: fmt.Errorf("failed to fetch webhooks (admin access is required): %w", err)}
+		}
+		items := make([]list.Item, len(hooks))
+		for i, h := range hooks {
+			items[i] = newWebhook(h)
+		}
+		return hooksFetchedMsg{hooks: items}
+	}
+}
+
+func createHook(owner, repo, hookURL string, events []string) tea.Cmd {
+	return func() tea.Msg {
+		hook := &github.Hook{
+			Config: map[string]interface{}{"url": hookURL, "content_type": "json"},
+			Events: events,
+			Active: github.Bool(true),
+		}
+		if _, _, err := newAPI().CreateHook(context.Background(), owner, repo, hook); err != nil {
+			return hookDoneMsg{err: fmt.Errorf("failed to add webhook: %w", err)}
+		}
+		return hookDoneMsg{status: tr("Added %s", hookURL)}
+	}
+}
+
+func deleteHook(owner, repo string, h webhook) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := newAPI().DeleteHook(context.Background(), owner, repo, h.id); err != nil {
+			return hookDoneMsg{err: fmt.Errorf("failed to delete webhook: %w", err)}
+		}
+		return hookDoneMsg{status: tr("Deleted %s", h.url)}
+	}
+}
+
+func pingHook(owner, repo string, h webhook) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := newAPI().PingHook(context.Background(), owner, repo, h.id); err != nil {
+			return hookDoneMsg{err: fmt.Errorf("failed to ping webhook: %w", err)}
+		}
+		return hookDoneMsg{status: tr("Pinged %s", h.url)}
+	}
+}
+
+// webhooksModel manages the webhooks of one repository.
+type webhooksModel struct {
+	it            item
+	list          list.Model
+	adding        bool
+	input         textinput.Model
+	confirmDelete bool
+	loading       bool
+	status        string
+	err           error
+}
+
+func newWebhooksModel(it item) webhooksModel {
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	localizeList(&l)
+	// d deletes, so it no longer pages
+	l.KeyMap.NextPage = key.NewBinding(key.WithKeys("right", "l", "pgdown", "f"), key.WithHelp("→/l/pgdn", tr("next page")))
+	l.Title = tr("%s/%s webhooks", it.owner, it.name)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("a"), key.WithHelp("a", tr("add"))),
+			key.NewBinding(key.WithKeys("d"), key.WithHelp("d", tr("delete"))),
+			key.NewBinding(key.WithKeys("p"), key.WithHelp("p", tr("ping"))),
+		}
+	}
+	return webhooksModel{it: it, list: l, loading: true}
+}
+
+func (m webhooksModel) crumbs() []string { return []string{"webhooks"} }
+
+func (m webhooksModel) Init() tea.Cmd {
+	return fetchHooks(m.it.owner, m.it.name)
+}
+
+func (m webhooksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case hooksFetchedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+		}
+		return m, m.list.SetItems(msg.hooks)
+	case hookDoneMsg:
+		m.status, m.err = msg.status, msg.err
+		return m, fetchHooks(m.it.owner, m.it.name)
+	case tea.KeyMsg:
+		if m.adding {
+			return m.updateAdd(msg)
+		}
+		if m.confirmDelete {
+			m.confirmDelete = false
+			h, ok := m.list.SelectedItem().(webhook)
+			if ok && msg.String() == "y" {
+				return m, deleteHook(m.it.owner, m.it.name, h)
+			}
+			return m, nil
+		}
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			if m.list.FilterState() == list.Unfiltered {
+				return m, goBack
+			}
+		case "a":
+			m.input = textinput.New()
+			m.input.Prompt = tr("webhook: ")
+			m.input.Placeholder = "https://example.com/hook push,pull_request"
+			m.input.CharLimit = 512
+			m.input.Focus()
+			m.adding = true
+			return m, textinput.Blink
+		case "d":
+			if _, ok := m.list.SelectedItem().(webhook); ok {
+				m.confirmDelete = true
+			}
+			return m, nil
+		case "p":
+			if h, ok := m.list.SelectedItem().(webhook); ok {
+				return m, pingHook(m.it.owner, m.it.name, h)
+			}
+			return m, nil
+		}
+	case tea.WindowSizeMsg:
+		h, v := normalStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v-3)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m webhooksModel) updateAdd(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.adding = false
+		return m, nil
+	case tea.KeyEnter:
+		hookURL, events, err := parseHookSpec(m.input.Value())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.adding = false
+		m.err = nil
+		return m, createHook(m.it.owner, m.it.name, hookURL, events)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m webhooksModel) View() string {
+	body := m.list.View()
+	switch {
+	case m.adding:
+		body = lipgloss.JoinVertical(lipgloss.Left, body, m.input.View())
+	case m.confirmDelete:
+		h, _ := m.list.SelectedItem().(webhook)
+		body = lipgloss.JoinVertical(lipgloss.Left, body, errorStyle.Render(tr("Delete %s? (y/n)", h.url)))
+	case m.loading:
+		body = lipgloss.JoinVertical(lipgloss.Left, body, tr("Loading webhooks..."))
+	}
+	if m.err != nil {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, errorStyle.Render(m.err.Error()))
+	} else if m.status != "" {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, successStyle.Render(m.status))
+	}
+	return normalStyle.Render(body)
+}
@@ -0,0 +1,218 @@
+package internals
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type wizardStep int
+
+const (
+	stepProvider wizardStep = iota
+	stepUser
+	stepToken
+	stepCloneDir
+	stepProtocol
+)
+
+var (
+	wizardProviders = []string{providerGitHub, providerGitLab}
+	wizardProtocols = []string{"https", "ssh"}
+)
+
+// wizardModel walks a first-time user through writing a config file.
+type wizardModel struct {
+	step     wizardStep
+	choice   int
+	provider string
+	user     string
+	token    string
+	cloneDir string
+	protocol string
+	input    textinput.Model
+	err      error
+
+	// confirmReplace is set when a default profile already exists, so it
+	// isn't replaced without asking.
+	confirmReplace bool
+}
+
+func newWizardModel(user string) wizardModel {
+	ti := textinput.New()
+	ti.CharLimit = 256
+	ti.Focus()
+	return wizardModel{input: ti, user: user}
+}
+
+func (m wizardModel) crumbs() []string { return []string{"setup"} }
+
+func (m wizardModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m wizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+c", "esc":
+		return m, tea.Quit
+	}
+	if m.confirmReplace {
+		switch keyMsg.String() {
+		case "y":
+			return m.finish("default", true)
+		case "n":
+			return m.finish(unusedProfileName("default"), false)
+		}
+		return m, nil
+	}
+	if keyMsg.String() == "enter" {
+		return m.advance()
+	}
+
+	if m.step == stepProvider || m.step == stepProtocol {
+		switch keyMsg.String() {
+		case "up", "k":
+			m.choice = max(m.choice-1, 0)
+		case "down", "j":
+			m.choice = min(m.choice+1, 1)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m wizardModel) advance() (tea.Model, tea.Cmd) {
+	value := strings.TrimSpace(m.input.Value())
+
+	switch m.step {
+	case stepProvider:
+		m.provider = wizardProviders[m.choice]
+		m.input.SetValue(m.user)
+	case stepUser:
+		m.user = value
+		m.input.SetValue("")
+		m.input.EchoMode = textinput.EchoPassword
+	case stepToken:
+		m.token = value
+		m.input.SetValue("~/src")
+		m.input.EchoMode = textinput.EchoNormal
+	case stepCloneDir:
+		m.cloneDir = value
+		m.choice = 0
+	case stepProtocol:
+		m.protocol = wizardProtocols[m.choice]
+		if _, ok := cfg.Profiles["default"]; ok {
+			m.confirmReplace = true
+			return m, nil
+		}
+		return m.finish("default", true)
+	}
+
+	m.step++
+	return m, nil
+}
+
+// finish saves the profile as name and opens its list.
+func (m wizardModel) finish(name string, makeDefault bool) (tea.Model, tea.Cmd) {
+	if err := m.save(name, makeDefault); err != nil {
+		m.confirmReplace = false
+		m.err = err
+		return m, nil
+	}
+	if m.user == "" {
+		return prepUsernameModel(""), textinput.Blink
+	}
+	return initialModel(m.user), nil
+}
+
+// unusedProfileName is base, or base with the first free number appended.
+func unusedProfileName(base string) string {
+	name := base
+	for n := 2; ; n++ {
+		if _, ok := cfg.Profiles[name]; !ok {
+			return name
+		}
+		name = base + "-" + strconv.Itoa(n)
+	}
+}
+
+func (m wizardModel) save(name string, makeDefault bool) error {
+	cfg.Protocol = m.protocol
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]profile)
+	}
+	cfg.Profiles[name] = profile{
+		Provider: m.provider,
+		CloneDir: m.cloneDir,
+		User:     m.user,
+	}
+	if makeDefault {
+		cfg.DefaultProfile = name
+	}
+	if err := saveConfig(); err != nil {
+		return fmt.Errorf("could not write config: %w", err)
+	}
+
+	if err := UseProfile(name); err != nil {
+		return err
+	}
+	if m.token != "" {
+		if _, err := saveToken(m.token); err != nil {
+			return fmt.Errorf("could not store token: %w", err)
+		}
+	}
+	return nil
+}
+
+func (m wizardModel) choiceView(options []string) string {
+	var b strings.Builder
+	for i, opt := range options {
+		if i == m.choice {
+			b.WriteString(cursorStyle.Render("> "+opt) + "\n")
+		} else {
+			b.WriteString("  " + opt + "\n")
+		}
+	}
+	return b.String()
+}
+
+func (m wizardModel) View() string {
+	var b strings.Builder
+	b.WriteString(tr("Welcome to gitls! Setup step %d of 5", m.step+1) + "\n\n")
+
+	switch m.step {
+	case stepProvider:
+		b.WriteString(tr("Which provider do you use?") + "\n\n" + m.choiceView(wizardProviders))
+	case stepUser:
+		b.WriteString(tr("Your username (leave empty to ask every time):") + "\n\n" + m.input.View())
+	case stepToken:
+		b.WriteString(tr("Paste a personal access token, or leave empty to browse anonymously:") + "\n\n" + m.input.View())
+	case stepCloneDir:
+		b.WriteString(tr("Default clone directory (empty for the current directory):") + "\n\n" + m.input.View())
+	case stepProtocol:
+		b.WriteString(tr("Clone over:") + "\n\n" + m.choiceView(wizardProtocols))
+	}
+
+	if m.err != nil {
+		b.WriteString("\n\n" + errorStyle.Render(m.err.Error()))
+	}
+	if m.confirmReplace {
+		b.WriteString("\n\n" + tr("A default profile already exists. y: replace it · n: keep it and save this one as %s", unusedProfileName("default")))
+	} else {
+		b.WriteString("\n\n" + tr("enter: next · esc: quit"))
+	}
+	return normalStyle.Render(b.String())
+}
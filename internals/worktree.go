@@ -0,0 +1,124 @@
+package internals
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// localBranches lists a repository's local and remote-tracking branches,
+// without the remote prefix, for completing the worktree prompt.
+func localBranches(path string) []string {
+	out, err := runGit("-C", path, "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var branches []string
+	for _, ref := range strings.Fields(string(out)) {
+		name, local := strings.CutPrefix(ref, "refs/heads/")
+		if !local {
+			// refs/remotes/<remote>/<branch>
+			parts := strings.SplitN(strings.TrimPrefix(ref, "refs/remotes/"), "/", 2)
+			if len(parts) < 2 || parts[1] == "HEAD" {
+				continue
+			}
+			name = parts[1]
+		}
+		if !seen[name] {
+			seen[name] = true
+			branches = append(branches, name)
+		}
+	}
+	return branches
+}
+
+func hasLocalBranch(path, branch string) bool {
+	_, err := runGit("-C", path, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// worktreeDest is the sibling directory a branch is checked out into,
+// e.g. gitls-feature-x next to gitls.
+func worktreeDest(path, branch string) string {
+	name := strings.NewReplacer("/", "-", "\\", "-", " ", "-").Replace(branch)
+	return filepath.Join(filepath.Dir(path), filepath.Base(path)+"-"+name)
+}
+
+// addWorktree checks branch out in a sibling directory of path. Branches
+// that only exist on a remote get a local tracking branch and unknown
+// names become new branches off HEAD.
+func addWorktree(path, branch string) (string, error) {
+	dest := worktreeDest(path, branch)
+	if _, err := os.Stat(dest); err == nil {
+		return "", fmt.Errorf("%s already exists", dest)
+	}
+	args := []string{"-C", path, "worktree", "add", dest, branch}
+	if !hasLocalBranch(path, branch) {
+		remote := false
+		for _, b := range localBranches(path) {
+			remote = remote || b == branch
+		}
+		if !remote {
+			args = []string{"-C", path, "worktree", "add", "-b", branch, dest}
+		}
+	}
+	if _, err := runGit(args...); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+type worktreeAddedMsg struct {
+	dest  string
+	items []localItem
+	err   error
+}
+
+func (m localModel) startWorktree() (tea.Model, tea.Cmd) {
+	it, ok := m.list.SelectedItem().(localItem)
+	if !ok {
+		return m, nil
+	}
+	ti := textinput.New()
+	ti.Prompt = tr("worktree branch: ")
+	ti.CharLimit = 128
+	ti.ShowSuggestions = true
+	ti.SetSuggestions(localBranches(it.path))
+	ti.Focus()
+	m.worktreeInput = ti
+	m.addingWorktree = true
+	return m, textinput.Blink
+}
+
+func (m localModel) updateWorktree(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.addingWorktree = false
+		return m, nil
+	case tea.KeyEnter:
+		branch := strings.TrimSpace(m.worktreeInput.Value())
+		it, ok := m.list.SelectedItem().(localItem)
+		if branch == "" || !ok {
+			return m, nil
+		}
+		m.addingWorktree = false
+		root := m.root
+		return m, func() tea.Msg {
+			dest, err := addWorktree(it.path, branch)
+			if err != nil {
+				return worktreeAddedMsg{err: err}
+			}
+			items, err := scanLocalRepos(root)
+			return worktreeAddedMsg{dest: dest, items: items, err: err}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.worktreeInput, cmd = m.worktreeInput.Update(msg)
+	return m, cmd
+}
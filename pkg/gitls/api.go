@@ -0,0 +1,203 @@
+package gitls
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// API is the part of the GitHub API gitls uses. Implement it to run gitls
+// against a fake or another backend.
+type API interface {
+	CurrentUser(ctx context.Context) (*github.User, *github.Response, error)
+	GetUser(ctx context.Context, login string) (*github.User, *github.Response, error)
+	SearchUsers(ctx context.Context, query string, opts *github.SearchOptions) (*github.UsersSearchResult, *github.Response, error)
+	ListRepos(ctx context.Context, user string, opts *github.RepositoryListOptions) ([]*github.Repository, *github.Response, error)
+	GetRepo(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
+	GetTree(ctx context.Context, owner, repo, ref string) (*github.Tree, *github.Response, error)
+	GetFile(ctx context.Context, owner, repo, path string) (*github.RepositoryContent, *github.Response, error)
+	ArchiveLink(ctx context.Context, owner, repo, ref string) (*url.URL, *github.Response, error)
+	SearchRepos(ctx context.Context, query string, opts *github.SearchOptions) (*github.RepositoriesSearchResult, *github.Response, error)
+	UserEvents(ctx context.Context, user string, opts *github.ListOptions) ([]*github.Event, *github.Response, error)
+	ListStarred(ctx context.Context, user string, opts *github.ActivityListStarredOptions) ([]*github.StarredRepository, *github.Response, error)
+	ListGists(ctx context.Context, user string, opts *github.GistListOptions) ([]*github.Gist, *github.Response, error)
+	ListTeams(ctx context.Context, org string, opts *github.ListOptions) ([]*github.Team, *github.Response, error)
+	ListTeamRepos(ctx context.Context, org, team string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error)
+	ListContributors(ctx context.Context, owner, repo string, opts *github.ListContributorsOptions) ([]*github.Contributor, *github.Response, error)
+	ListWorkflowRuns(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error)
+	ListNotifications(ctx context.Context, opts *github.NotificationListOptions) ([]*github.Notification, *github.Response, error)
+	MarkThreadRead(ctx context.Context, id string) (*github.Response, error)
+	TrafficViews(ctx context.Context, owner, repo string) (*github.TrafficViews, *github.Response, error)
+	TrafficClones(ctx context.Context, owner, repo string) (*github.TrafficClones, *github.Response, error)
+	ListHooks(ctx context.Context, owner, repo string) ([]*github.Hook, *github.Response, error)
+	CreateHook(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, *github.Response, error)
+	DeleteHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+	PingHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+	ListTags(ctx context.Context, owner, repo string) ([]*github.RepositoryTag, *github.Response, error)
+	LatestRelease(ctx context.Context, owner, repo string) (*github.RepositoryRelease, *github.Response, error)
+	// ListLanguages returns bytes of code per language.
+	ListLanguages(ctx context.Context, owner, repo string) (map[string]int, *github.Response, error)
+	// The key methods manage a repository's deploy keys, or the
+	// authenticated user's SSH keys when repo is empty.
+	ListKeys(ctx context.Context, owner, repo string) ([]*github.Key, *github.Response, error)
+	CreateKey(ctx context.Context, owner, repo string, key *github.Key) (*github.Key, *github.Response, error)
+	DeleteKey(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+	// Query runs a GraphQL query and decodes the whole response into out.
+	Query(ctx context.Context, query string, vars map[string]any, out any) (*github.Response, error)
+}
+
+// NewGitHubAPI implements API with a go-github client.
+func NewGitHubAPI(client *github.Client) API {
+	return restAPI{client}
+}
+
+type restAPI struct {
+	client *github.Client
+}
+
+func (a restAPI) CurrentUser(ctx context.Context) (*github.User, *github.Response, error) {
+	return a.client.Users.Get(ctx, "")
+}
+
+func (a restAPI) GetUser(ctx context.Context, login string) (*github.User, *github.Response, error) {
+	return a.client.Users.Get(ctx, login)
+}
+
+func (a restAPI) SearchUsers(ctx context.Context, query string, opts *github.SearchOptions) (*github.UsersSearchResult, *github.Response, error) {
+	return a.client.Search.Users(ctx, query, opts)
+}
+
+func (a restAPI) ListRepos(ctx context.Context, user string, opts *github.RepositoryListOptions) ([]*github.Repository, *github.Response, error) {
+	return a.client.Repositories.List(ctx, user, opts)
+}
+
+func (a restAPI) GetRepo(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	return a.client.Repositories.Get(ctx, owner, repo)
+}
+
+func (a restAPI) GetTree(ctx context.Context, owner, repo, ref string) (*github.Tree, *github.Response, error) {
+	return a.client.Git.GetTree(ctx, owner, repo, ref, false)
+}
+
+func (a restAPI) GetFile(ctx context.Context, owner, repo, path string) (*github.RepositoryContent, *github.Response, error) {
+	file, _, resp, err := a.client.Repositories.GetContents(ctx, owner, repo, path, nil)
+	return file, resp, err
+}
+
+func (a restAPI) ArchiveLink(ctx context.Context, owner, repo, ref string) (*url.URL, *github.Response, error) {
+	opts := &github.RepositoryContentGetOptions{Ref: ref}
+	return a.client.Repositories.GetArchiveLink(ctx, owner, repo, github.Tarball, opts, false)
+}
+
+func (a restAPI) SearchRepos(ctx context.Context, query string, opts *github.SearchOptions) (*github.RepositoriesSearchResult, *github.Response, error) {
+	return a.client.Search.Repositories(ctx, query, opts)
+}
+
+func (a restAPI) UserEvents(ctx context.Context, user string, opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+	return a.client.Activity.ListEventsPerformedByUser(ctx, user, true, opts)
+}
+
+func (a restAPI) ListTeams(ctx context.Context, org string, opts *github.ListOptions) ([]*github.Team, *github.Response, error) {
+	return a.client.Teams.ListTeams(ctx, org, opts)
+}
+
+func (a restAPI) ListStarred(ctx context.Context, user string, opts *github.ActivityListStarredOptions) ([]*github.StarredRepository, *github.Response, error) {
+	return a.client.Activity.ListStarred(ctx, user, opts)
+}
+
+func (a restAPI) ListGists(ctx context.Context, user string, opts *github.GistListOptions) ([]*github.Gist, *github.Response, error) {
+	return a.client.Gists.List(ctx, user, opts)
+}
+
+func (a restAPI) ListTeamRepos(ctx context.Context, org, team string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	return a.client.Teams.ListTeamReposBySlug(ctx, org, team, opts)
+}
+
+func (a restAPI) ListContributors(ctx context.Context, owner, repo string, opts *github.ListContributorsOptions) ([]*github.Contributor, *github.Response, error) {
+	return a.client.Repositories.ListContributors(ctx, owner, repo, opts)
+}
+
+func (a restAPI) ListWorkflowRuns(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	return a.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+}
+
+func (a restAPI) ListNotifications(ctx context.Context, opts *github.NotificationListOptions) ([]*github.Notification, *github.Response, error) {
+	return a.client.Activity.ListNotifications(ctx, opts)
+}
+
+func (a restAPI) MarkThreadRead(ctx context.Context, id string) (*github.Response, error) {
+	return a.client.Activity.MarkThreadRead(ctx, id)
+}
+
+func (a restAPI) TrafficViews(ctx context.Context, owner, repo string) (*github.TrafficViews, *github.Response, error) {
+	return a.client.Repositories.ListTrafficViews(ctx, owner, repo, &github.TrafficBreakdownOptions{Per: "day"})
+}
+
+func (a restAPI) TrafficClones(ctx context.Context, owner, repo string) (*github.TrafficClones, *github.Response, error) {
+	return a.client.Repositories.ListTrafficClones(ctx, owner, repo, &github.TrafficBreakdownOptions{Per: "day"})
+}
+
+func (a restAPI) ListHooks(ctx context.Context, owner, repo string) ([]*github.Hook, *github.Response, error) {
+	return a.client.Repositories.ListHooks(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+}
+
+func (a restAPI) CreateHook(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, *github.Response, error) {
+	return a.client.Repositories.CreateHook(ctx, owner, repo, hook)
+}
+
+func (a restAPI) DeleteHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	return a.client.Repositories.DeleteHook(ctx, owner, repo, id)
+}
+
+func (a restAPI) PingHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	return a.client.Repositories.PingHook(ctx, owner, repo, id)
+}
+
+func (a restAPI) ListTags(ctx context.Context, owner, repo string) ([]*github.RepositoryTag, *github.Response, error) {
+	return a.client.Repositories.ListTags(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+}
+
+func (a restAPI) LatestRelease(ctx context.Context, owner, repo string) (*github.RepositoryRelease, *github.Response, error) {
+	return a.client.Repositories.GetLatestRelease(ctx, owner, repo)
+}
+
+func (a restAPI) ListLanguages(ctx context.Context, owner, repo string) (map[string]int, *github.Response, error) {
+	return a.client.Repositories.ListLanguages(ctx, owner, repo)
+}
+
+func (a restAPI) ListKeys(ctx context.Context, owner, repo string) ([]*github.Key, *github.Response, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	if repo == "" {
+		return a.client.Users.ListKeys(ctx, "", opts)
+	}
+	return a.client.Repositories.ListKeys(ctx, owner, repo, opts)
+}
+
+func (a restAPI) CreateKey(ctx context.Context, owner, repo string, key *github.Key) (*github.Key, *github.Response, error) {
+	if repo == "" {
+		return a.client.Users.CreateKey(ctx, key)
+	}
+	return a.client.Repositories.CreateKey(ctx, owner, repo, key)
+}
+
+func (a restAPI) DeleteKey(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	if repo == "" {
+		return a.client.Users.DeleteKey(ctx, id)
+	}
+	return a.client.Repositories.DeleteKey(ctx, owner, repo, id)
+}
+
+func (a restAPI) Query(ctx context.Context, query string, vars map[string]any, out any) (*github.Response, error) {
+	// relative to the REST base URL: api.github.com serves GraphQL at
+	// /graphql, Enterprise at /api/graphql next to /api/v3
+	path := "graphql"
+	if a.client.BaseURL.Host != "api.github.com" {
+		path = "../graphql"
+	}
+	req, err := a.client.NewRequest("POST", path, map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return nil, err
+	}
+	return a.client.Do(ctx, req, out)
+}
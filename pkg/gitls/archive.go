@@ -0,0 +1,167 @@
+package gitls
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// archiveRequest builds the request for a repository's source tarball.
+func (c *Client) archiveRequest(ctx context.Context, owner, name, ref string) (*http.Request, error) {
+	if c.opts.Provider == GitLab {
+		project := url.PathEscape(owner + "/" + name)
+		u := fmt.Sprintf("%s/projects/%s/repository/archive.tar.gz", c.gitlabAPI(), project)
+		if ref != "" {
+			u += "?sha=" + url.QueryEscape(ref)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		if c.opts.Token != "" {
+			req.Header.Set("PRIVATE-TOKEN", c.opts.Token)
+		}
+		return req, nil
+	}
+
+	// the API answers with a redirect to a short-lived download URL that
+	// needs no token
+	link, _, err := c.api.ArchiveLink(ctx, owner, name, ref)
+	if err != nil {
+		return nil, err
+	}
+	return http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+}
+
+// DownloadArchive downloads the source of owner/name at ref (the default
+// branch when empty) and extracts it into dest without git. dest must not
+// exist yet and is removed again if extraction fails.
+func (c *Client) DownloadArchive(ctx context.Context, owner, name, ref, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s already exists", dest)
+	}
+
+	req, err := c.archiveRequest(ctx, owner, name, ref)
+	if err != nil {
+		return fmt.Errorf("failed to get archive link: %w", err)
+	}
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download archive: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download archive: %s", resp.Status)
+	}
+
+	if err := ExtractTarGz(resp.Body, dest); err != nil {
+		os.RemoveAll(dest)
+		return fmt.Errorf("failed to extract archive: %w", err)
+	}
+	return nil
+}
+
+// ExtractTarGz unpacks a source archive into dest, dropping the single
+// top-level directory (owner-repo-sha/) both providers wrap it in.
+func ExtractTarGz(r io.Reader, dest string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, rel, ok := strings.Cut(hdr.Name, "/")
+		if !ok || rel == "" {
+			continue
+		}
+		target := filepath.Join(dest, rel)
+		if !within(dest, target) {
+			return fmt.Errorf("%s escapes the destination", hdr.Name)
+		}
+		if err := noSymlinkParent(dest, target); err != nil {
+			return fmt.Errorf("%s: %w", hdr.Name, err)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr, hdr.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if filepath.IsAbs(hdr.Linkname) || !within(dest, filepath.Join(filepath.Dir(target), hdr.Linkname)) {
+				return fmt.Errorf("%s links outside the destination", hdr.Name)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// within reports whether path is below dir.
+func within(dir, path string) bool {
+	return strings.HasPrefix(filepath.Clean(path), filepath.Clean(dir)+string(os.PathSeparator))
+}
+
+// noSymlinkParent refuses to write target through a symlink extracted
+// earlier, which may point somewhere a chain of links leads out of dest.
+func noSymlinkParent(dest, target string) error {
+	rel, err := filepath.Rel(dest, filepath.Dir(target))
+	if err != nil || rel == "." {
+		return err
+	}
+	path := filepath.Clean(dest)
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		path = filepath.Join(path, part)
+		info, err := os.Lstat(path)
+		if err != nil {
+			return nil // not created yet, so neither is anything below it
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink", path)
+		}
+	}
+	return nil
+}
+
+func writeArchiveFile(path string, r io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
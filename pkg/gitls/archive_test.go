@@ -0,0 +1,132 @@
+package gitls
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	body     string
+}
+
+func tarGz(t *testing.T, entries []tarEntry) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Typeflag: e.typeflag, Linkname: e.linkname, Mode: 0o644, Size: int64(len(e.body))}
+		if e.typeflag == tar.TypeDir {
+			hdr.Mode = 0o755
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestExtractTarGz(t *testing.T) {
+	entries := []tarEntry{
+		{name: "owner-repo-sha/", typeflag: tar.TypeDir},
+		{name: "owner-repo-sha/README.md", typeflag: tar.TypeReg, body: "hello"},
+		{name: "owner-repo-sha/cmd/main.go", typeflag: tar.TypeReg, body: "package main"},
+		{name: "owner-repo-sha/docs/link", typeflag: tar.TypeSymlink, linkname: "../README.md"},
+	}
+	dest := filepath.Join(t.TempDir(), "repo")
+	if err := ExtractTarGz(tarGz(t, entries), dest); err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{
+		"README.md":   "hello",
+		"cmd/main.go": "package main",
+		"docs/link":   "hello",
+	} {
+		got, err := os.ReadFile(filepath.Join(dest, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestExtractTarGzRefusesEscapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+		wantErr string
+	}{
+		{
+			name:    "dot dot",
+			entries: []tarEntry{{name: "top/../../evil", typeflag: tar.TypeReg, body: "x"}},
+			wantErr: "escapes the destination",
+		},
+		{
+			name:    "dot dot in a directory",
+			entries: []tarEntry{{name: "top/a/../../../evil/", typeflag: tar.TypeDir}},
+			wantErr: "escapes the destination",
+		},
+		{
+			name:    "absolute symlink",
+			entries: []tarEntry{{name: "top/link", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"}},
+			wantErr: "links outside the destination",
+		},
+		{
+			name:    "relative symlink out",
+			entries: []tarEntry{{name: "top/a/link", typeflag: tar.TypeSymlink, linkname: "../../../evil"}},
+			wantErr: "links outside the destination",
+		},
+		{
+			name: "write through a symlink",
+			entries: []tarEntry{
+				{name: "top/dir", typeflag: tar.TypeSymlink, linkname: "sub"},
+				{name: "top/dir/file", typeflag: tar.TypeReg, body: "x"},
+			},
+			wantErr: "is a symlink",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			dest := filepath.Join(root, "dest")
+			err := ExtractTarGz(tarGz(t, tt.entries), dest)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			if _, err := os.Lstat(filepath.Join(root, "evil")); err == nil {
+				t.Error("evil was written outside the destination")
+			}
+		})
+	}
+}
+
+func TestExtractTarGzKeepsAbsoluteNamesInside(t *testing.T) {
+	// the leading "/" makes an empty top-level directory, which is dropped
+	entries := []tarEntry{{name: "/evil", typeflag: tar.TypeReg, body: "x"}}
+	dest := filepath.Join(t.TempDir(), "dest")
+	if err := ExtractTarGz(tarGz(t, entries), dest); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "evil")); err != nil {
+		t.Fatalf("evil was not extracted below dest: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package gitls
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/google/go-github/v50/github"
+	"golang.org/x/oauth2"
+)
+
+// Repository is the repository type returned for every provider.
+type Repository = github.Repository
+
+// Provider selects the hosting service.
+type Provider string
+
+const (
+	GitHub Provider = "github"
+	GitLab Provider = "gitlab"
+)
+
+// Options configure a Client. The zero value lists public GitHub
+// repositories anonymously.
+type Options struct {
+	Provider Provider // GitHub when empty
+	Token    string
+	// BaseURL is the API root for GitHub Enterprise or self-hosted GitLab.
+	BaseURL    string
+	HTTPClient *http.Client // http.DefaultClient when nil
+	Logger     *slog.Logger // discards when nil
+	// NewAPI replaces go-github, e.g. with a fake in tests. It is called
+	// with an empty token when the configured one is rejected.
+	NewAPI func(token string) API
+}
+
+// Client talks to one provider with one set of credentials. It is safe for
+// concurrent use.
+type Client struct {
+	opts Options
+	api  API
+	meta sync.Map // full name -> RepoMeta
+}
+
+func New(opts Options) *Client {
+	if opts.Provider == "" {
+		opts.Provider = GitHub
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.DiscardHandler)
+	}
+	c := &Client{opts: opts}
+	c.api = c.newAPI(opts.Token)
+	return c
+}
+
+// API returns the GitHub API calls for the client's token.
+func (c *Client) API() API {
+	return c.api
+}
+
+func (c *Client) newAPI(token string) API {
+	if c.opts.NewAPI != nil {
+		return c.opts.NewAPI(token)
+	}
+	return NewGitHubAPI(c.githubClient(token))
+}
+
+func (c *Client) githubClient(token string) *github.Client {
+	httpClient := c.opts.HTTPClient
+	if token != "" {
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+		httpClient = oauth2.NewClient(ctx, ts)
+	}
+
+	if c.opts.BaseURL != "" {
+		client, err := github.NewEnterpriseClient(c.opts.BaseURL, c.opts.BaseURL, httpClient)
+		if err == nil {
+			return client
+		}
+		c.opts.Logger.Warn("invalid enterprise base url", "url", c.opts.BaseURL, "err", err)
+	}
+	return github.NewClient(httpClient)
+}
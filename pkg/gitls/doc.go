@@ -0,0 +1,19 @@
+// Package gitls lists and clones the repositories of a GitHub or GitLab
+// account. It is the library behind the gitls TUI and can be embedded by
+// other tools:
+//
+//	c := gitls.New(gitls.Options{Token: os.Getenv("GITHUB_TOKEN")})
+//	repos, _, err := c.ListRepos(ctx, "charmbracelet")
+//	if err != nil {
+//		return err
+//	}
+//	for _, repo := range repos {
+//		dest := gitls.Dest(gitls.LayoutFlat, "src", repo.GetCloneURL(), repo.GetOwner().GetLogin(), repo.GetName())
+//		if err := (gitls.Git{}).Clone(ctx, repo.GetCloneURL(), dest, gitls.CloneOptions{}); err != nil {
+//			return err
+//		}
+//	}
+//
+// Repositories are go-github's Repository type for both providers; GitLab
+// projects are mapped onto it and leave GitHub-only fields empty.
+package gitls
@@ -0,0 +1,74 @@
+package gitls
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// Git runs the git executable. The zero value runs git from PATH with the
+// current environment.
+type Git struct {
+	Path     string    // git executable, "git" when empty
+	Env      []string  // added to the environment of every command
+	Rewrites []Rewrite // applied to the URL of every clone
+	Logger   *slog.Logger
+}
+
+// Run runs git with args and extra environment variables and returns its
+// combined output. Errors are a *GitError, which includes the output.
+func (g Git) Run(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	path := g.Path
+	if path == "" {
+		path = "git"
+	}
+	start := time.Now()
+	cmd := exec.CommandContext(ctx, path, args...)
+	if env = append(append([]string{}, g.Env...), env...); len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	output, err := cmd.CombinedOutput()
+	if g.Logger != nil {
+		g.Logger.Debug("git command", "args", cmd.Args, "took", time.Since(start), "err", err)
+	}
+	if err != nil {
+		return output, &GitError{Kind: classifyGitOutput(string(output)), Args: args, Output: string(output), Err: err}
+	}
+	return output, nil
+}
+
+// CloneOptions adjust a clone.
+type CloneOptions struct {
+	Mirror bool     // bare mirror clone
+	Filter string   // partial clone filter, e.g. blob:none
+	Depth  int      // shallow clone depth, 0 for full history
+	Sparse bool     // start with a sparse checkout of the top level
+	Branch string   // branch or tag to check out instead of the default branch
+	Env    []string // extra environment, e.g. GIT_LFS_SKIP_SMUDGE=1
+}
+
+// Clone clones url, after applying g.Rewrites, into dest.
+func (g Git) Clone(ctx context.Context, url, dest string, opts CloneOptions) error {
+	args := []string{"clone"}
+	if opts.Mirror {
+		args = append(args, "--mirror")
+	}
+	if opts.Filter != "" {
+		args = append(args, "--filter="+opts.Filter)
+	}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if opts.Sparse {
+		args = append(args, "--sparse")
+	}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	args = append(args, RewriteURL(url, g.Rewrites), dest)
+	_, err := g.Run(ctx, opts.Env, args...)
+	return err
+}
@@ -0,0 +1,77 @@
+package gitls
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GitErrorKind is the cause of a failed git command, as far as its output
+// tells.
+type GitErrorKind int
+
+const (
+	GitErrUnknown  GitErrorKind = iota
+	GitErrAuth                  // credentials missing or rejected
+	GitErrHostKey               // SSH host key unknown or changed
+	GitErrDiskFull              // no space or quota left
+	GitErrNotFound              // no such repository, or no access to it
+)
+
+func (k GitErrorKind) String() string {
+	switch k {
+	case GitErrAuth:
+		return "authentication failed"
+	case GitErrHostKey:
+		return "host key verification failed"
+	case GitErrDiskFull:
+		return "disk full"
+	case GitErrNotFound:
+		return "repository not found"
+	default:
+		return "git failed"
+	}
+}
+
+// GitError is a failed git command. Use errors.As to get at the kind; the
+// underlying *exec.ExitError is still reachable through Unwrap.
+type GitError struct {
+	Kind   GitErrorKind
+	Args   []string
+	Output string // combined stdout and stderr
+	Err    error
+}
+
+func (e *GitError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Output)
+}
+
+func (e *GitError) Unwrap() error {
+	return e.Err
+}
+
+// gitErrorPatterns are checked in order, so a more specific cause wins when
+// git prints several: GitHub answers an SSH clone of a missing repository
+// with both "Repository not found" and "make sure you have the correct
+// access rights".
+var gitErrorPatterns = []struct {
+	kind    GitErrorKind
+	markers []string
+}{
+	{GitErrHostKey, []string{"host key verification failed", "remote host identification has changed", "no ed25519 host key is known", "no ecdsa host key is known", "no rsa host key is known"}},
+	{GitErrDiskFull, []string{"no space left on device", "disk quota exceeded"}},
+	{GitErrNotFound, []string{"repository not found", "the requested url returned error: 404", "does not appear to be a git repository", "remote: not found"}},
+	{GitErrAuth, []string{"authentication failed", "permission denied (publickey", "could not read username", "could not read password", "terminal prompts disabled", "access denied", "the requested url returned error: 401", "the requested url returned error: 403"}},
+}
+
+// classifyGitOutput guesses why git failed from what it printed.
+func classifyGitOutput(output string) GitErrorKind {
+	output = strings.ToLower(output)
+	for _, p := range gitErrorPatterns {
+		for _, marker := range p.markers {
+			if strings.Contains(output, marker) {
+				return p.kind
+			}
+		}
+	}
+	return GitErrUnknown
+}
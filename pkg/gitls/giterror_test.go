@@ -0,0 +1,30 @@
+package gitls
+
+import "testing"
+
+func TestClassifyGitOutput(t *testing.T) {
+	tests := []struct {
+		output string
+		want   GitErrorKind
+	}{
+		{"fatal: Authentication failed for 'https://github.com/a/b.git/'", GitErrAuth},
+		{"git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.", GitErrAuth},
+		{"fatal: could not read Username for 'https://github.com': terminal prompts disabled", GitErrAuth},
+		{"fatal: unable to access 'https://github.com/a/b.git/': The requested URL returned error: 403", GitErrAuth},
+		{"Host key verification failed.\nfatal: Could not read from remote repository.", GitErrHostKey},
+		{"@@@ WARNING: REMOTE HOST IDENTIFICATION HAS CHANGED! @@@", GitErrHostKey},
+		{"fatal: write error: No space left on device", GitErrDiskFull},
+		{"error: Disk quota exceeded", GitErrDiskFull},
+		{"remote: Repository not found.\nfatal: repository 'https://github.com/a/b.git/' not found", GitErrNotFound},
+		// GitHub prints both over SSH; the missing repository is the cause
+		{"ERROR: Repository not found.\nfatal: Could not read from remote repository.\n\nPlease make sure you have the correct access rights", GitErrNotFound},
+		{"fatal: 'nope' does not appear to be a git repository", GitErrNotFound},
+		{"fatal: destination path 'b' already exists and is not an empty directory.", GitErrUnknown},
+		{"", GitErrUnknown},
+	}
+	for _, tt := range tests {
+		if got := classifyGitOutput(tt.output); got != tt.want {
+			t.Errorf("classifyGitOutput(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
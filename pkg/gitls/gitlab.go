@@ -0,0 +1,116 @@
+package gitls
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+)
+
+type gitlabProject struct {
+	Name              string    `json:"name"`
+	Description       string    `json:"description"`
+	HTTPURLToRepo     string    `json:"http_url_to_repo"`
+	SSHURLToRepo      string    `json:"ssh_url_to_repo"`
+	Visibility        string    `json:"visibility"`
+	Archived          bool      `json:"archived"`
+	StarCount         int       `json:"star_count"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	DefaultBranch     string    `json:"default_branch"`
+	ForkedFromProject *struct{} `json:"forked_from_project"`
+	Topics            []string  `json:"topics"`
+	Namespace         struct {
+		Path string `json:"path"`
+	} `json:"namespace"`
+}
+
+// toRepository maps a GitLab project onto the GitHub type; fields GitLab
+// doesn't have are left empty.
+func (p gitlabProject) toRepository() *github.Repository {
+	return &github.Repository{
+		Name:            github.String(p.Name),
+		Description:     github.String(p.Description),
+		CloneURL:        github.String(p.HTTPURLToRepo),
+		SSHURL:          github.String(p.SSHURLToRepo),
+		Private:         github.Bool(p.Visibility != "public"),
+		Fork:            github.Bool(p.ForkedFromProject != nil),
+		Archived:        github.Bool(p.Archived),
+		StargazersCount: github.Int(p.StarCount),
+		UpdatedAt:       &github.Timestamp{Time: p.LastActivityAt},
+		DefaultBranch:   github.String(p.DefaultBranch),
+		Owner:           &github.User{Login: github.String(p.Namespace.Path)},
+		Topics:          p.Topics,
+	}
+}
+
+func (c *Client) gitlabAPI() string {
+	if c.opts.BaseURL != "" {
+		return strings.TrimSuffix(c.opts.BaseURL, "/")
+	}
+	return "https://gitlab.com/api/v4"
+}
+
+func (c *Client) listGitLab(ctx context.Context, username string) ([]*github.Repository, ListInfo, error) {
+	repos, info, err := c.listGitLabProjects(ctx, "users", username)
+	var notFound gitlabNotFound
+	if errors.As(err, &notFound) {
+		// not a user, the name may belong to a group
+		return c.listGitLabProjects(ctx, "groups", username)
+	}
+	return repos, info, err
+}
+
+type gitlabNotFound struct{ path string }
+
+func (e gitlabNotFound) Error() string { return "gitlab: " + e.path + " not found" }
+
+func (c *Client) listGitLabProjects(ctx context.Context, kind, name string) ([]*github.Repository, ListInfo, error) {
+	var info ListInfo
+	var repos []*github.Repository
+	page := "1"
+	for page != "" {
+		u := fmt.Sprintf("%s/%s/%s/projects?per_page=100&page=%s", c.gitlabAPI(), kind, url.PathEscape(name), page)
+		if kind == "groups" {
+			u += "&include_subgroups=true"
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, info, err
+		}
+		if c.opts.Token != "" {
+			req.Header.Set("PRIVATE-TOKEN", c.opts.Token)
+		}
+
+		resp, err := c.opts.HTTPClient.Do(req)
+		if err != nil {
+			return nil, info, fmt.Errorf("failed to list projects: %w", err)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return nil, info, gitlabNotFound{path: kind + "/" + name}
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, info, fmt.Errorf("failed to list projects: %s", resp.Status)
+		}
+		var projects []gitlabProject
+		err = json.NewDecoder(resp.Body).Decode(&projects)
+		resp.Body.Close()
+		if err != nil {
+			return nil, info, fmt.Errorf("failed to decode projects: %w", err)
+		}
+
+		for _, p := range projects {
+			repos = append(repos, p.toRepository())
+		}
+		page = resp.Header.Get("X-Next-Page")
+	}
+
+	return repos, info, nil
+}
@@ -0,0 +1,182 @@
+package gitls
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// RepoMeta is metadata the REST listing doesn't include. It is available
+// after a GraphQL listing.
+type RepoMeta struct {
+	Languages []string // by size, largest first
+	Readme    string
+}
+
+// Meta returns what the last GraphQL listing found about the repository
+// with the given owner/name.
+func (c *Client) Meta(fullName string) (RepoMeta, bool) {
+	v, ok := c.meta.Load(fullName)
+	if !ok {
+		return RepoMeta{}, false
+	}
+	return v.(RepoMeta), true
+}
+
+const reposQuery = `query($login: String!, $cursor: String) {
+  repositoryOwner(login: $login) {
+    repositories(first: 100, after: $cursor) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        name
+        nameWithOwner
+        description
+        url
+        sshUrl
+        isPrivate
+        isFork
+        isArchived
+        isTemplate
+        stargazerCount
+        diskUsage
+        updatedAt
+        pushedAt
+        owner { login }
+        primaryLanguage { name }
+        defaultBranchRef { name }
+        licenseInfo { spdxId }
+        repositoryTopics(first: 20) { nodes { topic { name } } }
+        languages(first: 5, orderBy: {field: SIZE, direction: DESC}) { nodes { name } }
+        readme: object(expression: "HEAD:README.md") { ... on Blob { text } }
+      }
+    }
+  }
+}`
+
+type gqlRepo struct {
+	Name             string
+	NameWithOwner    string
+	Description      string
+	URL              string
+	SSHURL           string `json:"sshUrl"`
+	IsPrivate        bool
+	IsFork           bool
+	IsArchived       bool
+	IsTemplate       bool
+	StargazerCount   int
+	DiskUsage        int
+	UpdatedAt        time.Time
+	PushedAt         time.Time
+	Owner            struct{ Login string }
+	PrimaryLanguage  *struct{ Name string }
+	DefaultBranchRef *struct{ Name string }
+	LicenseInfo      *struct{ SpdxID string }
+	RepositoryTopics struct {
+		Nodes []struct{ Topic struct{ Name string } }
+	}
+	Languages struct {
+		Nodes []struct{ Name string }
+	}
+	Readme *struct{ Text string }
+}
+
+type gqlReposResponse struct {
+	Data struct {
+		RepositoryOwner *struct {
+			Repositories struct {
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+				Nodes []gqlRepo
+			}
+		}
+	}
+	Errors []struct{ Message string }
+}
+
+func (r gqlRepo) toRepository() *github.Repository {
+	repo := &github.Repository{
+		Name:            github.String(r.Name),
+		FullName:        github.String(r.NameWithOwner),
+		Description:     github.String(r.Description),
+		HTMLURL:         github.String(r.URL),
+		CloneURL:        github.String(r.URL + ".git"),
+		SSHURL:          github.String(r.SSHURL),
+		Private:         github.Bool(r.IsPrivate),
+		Fork:            github.Bool(r.IsFork),
+		Archived:        github.Bool(r.IsArchived),
+		IsTemplate:      github.Bool(r.IsTemplate),
+		StargazersCount: github.Int(r.StargazerCount),
+		Size:            github.Int(r.DiskUsage),
+		UpdatedAt:       &github.Timestamp{Time: r.UpdatedAt},
+		PushedAt:        &github.Timestamp{Time: r.PushedAt},
+		Owner:           &github.User{Login: github.String(r.Owner.Login)},
+	}
+	if r.PrimaryLanguage != nil {
+		repo.Language = github.String(r.PrimaryLanguage.Name)
+	}
+	if r.DefaultBranchRef != nil {
+		repo.DefaultBranch = github.String(r.DefaultBranchRef.Name)
+	}
+	if r.LicenseInfo != nil {
+		repo.License = &github.License{SPDXID: github.String(r.LicenseInfo.SpdxID)}
+	}
+	for _, n := range r.RepositoryTopics.Nodes {
+		repo.Topics = append(repo.Topics, n.Topic.Name)
+	}
+	return repo
+}
+
+func (r gqlRepo) meta() RepoMeta {
+	var meta RepoMeta
+	for _, n := range r.Languages.Nodes {
+		meta.Languages = append(meta.Languages, n.Name)
+	}
+	if r.Readme != nil {
+		meta.Readme = r.Readme.Text
+	}
+	return meta
+}
+
+// listGraphQL lists repositories with their languages, topics and README
+// in one request per 100 repos. GraphQL needs a token.
+func (c *Client) listGraphQL(ctx context.Context, api API, username string, info *ListInfo) ([]*github.Repository, error) {
+	var repos []*github.Repository
+	var cursor *string
+	for page := 1; ; page++ {
+		var out gqlReposResponse
+		vars := map[string]any{"login": username, "cursor": cursor}
+		resp, err := api.Query(ctx, reposQuery, vars, &out)
+		if err != nil {
+			return nil, err
+		}
+		info.Rate = resp.Rate
+
+		if len(out.Errors) > 0 {
+			msgs := make([]string, len(out.Errors))
+			for i, e := range out.Errors {
+				msgs[i] = e.Message
+			}
+			return nil, fmt.Errorf("graphql: %s", strings.Join(msgs, "; "))
+		}
+		owner := out.Data.RepositoryOwner
+		if owner == nil {
+			return nil, fmt.Errorf("graphql: no user or organization named %q", username)
+		}
+
+		for _, r := range owner.Repositories.Nodes {
+			repos = append(repos, r.toRepository())
+			c.meta.Store(r.NameWithOwner, r.meta())
+		}
+		c.opts.Logger.Debug("fetched graphql page", "user", username, "page", page, "count", len(owner.Repositories.Nodes))
+
+		if !owner.Repositories.PageInfo.HasNextPage {
+			return repos, nil
+		}
+		cursor = &owner.Repositories.PageInfo.EndCursor
+	}
+}
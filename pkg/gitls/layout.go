@@ -0,0 +1,37 @@
+package gitls
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// Layout decides where below a root directory a repository is cloned.
+type Layout string
+
+const (
+	LayoutFlat Layout = "flat" // <root>/<repo>
+	LayoutGhq  Layout = "ghq"  // <root>/<host>/<owner>/<repo>, as ghq lays out clones
+)
+
+// Host extracts the host from an HTTPS or scp-style SSH clone URL.
+func Host(cloneURL string) string {
+	if u, err := url.Parse(cloneURL); err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+	// git@github.com:owner/repo.git
+	if _, rest, ok := strings.Cut(cloneURL, "@"); ok {
+		if host, _, ok := strings.Cut(rest, ":"); ok {
+			return host
+		}
+	}
+	return "unknown"
+}
+
+// Dest is where a repository goes below root in the given layout.
+func Dest(layout Layout, root, cloneURL, owner, name string) string {
+	if layout == LayoutGhq {
+		return filepath.Join(root, Host(cloneURL), owner, name)
+	}
+	return filepath.Join(root, name)
+}
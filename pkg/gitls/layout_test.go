@@ -0,0 +1,26 @@
+package gitls
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDest(t *testing.T) {
+	tests := []struct {
+		layout   Layout
+		cloneURL string
+		want     string
+	}{
+		{LayoutFlat, "https://github.com/alice/tool.git", filepath.Join("root", "tool")},
+		{"", "https://github.com/alice/tool.git", filepath.Join("root", "tool")},
+		{LayoutGhq, "https://github.com/alice/tool.git", filepath.Join("root", "github.com", "alice", "tool")},
+		{LayoutGhq, "git@gitlab.example.com:alice/tool.git", filepath.Join("root", "gitlab.example.com", "alice", "tool")},
+		{LayoutGhq, "ssh://git@github.com:22/alice/tool.git", filepath.Join("root", "github.com", "alice", "tool")},
+		{LayoutGhq, "not a url", filepath.Join("root", "unknown", "alice", "tool")},
+	}
+	for _, tt := range tests {
+		if got := Dest(tt.layout, "root", tt.cloneURL, "alice", "tool"); got != tt.want {
+			t.Errorf("Dest(%q, %q) = %q, want %q", tt.layout, tt.cloneURL, got, tt.want)
+		}
+	}
+}
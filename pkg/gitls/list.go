@@ -0,0 +1,96 @@
+package gitls
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// ListInfo describes the credentials and rate limit a listing ran with.
+type ListInfo struct {
+	Login        string   // authenticated user, empty when anonymous
+	Scopes       []string // classic token scopes, empty for fine-grained tokens
+	TokenWarning string   // problem with the token worth showing the user
+	Rate         github.Rate
+}
+
+// ListRepos returns every repository of owner, a user or organization (a
+// group on GitLab). With a valid GitHub token it uses GraphQL, which also
+// fills in Meta; otherwise, or when GraphQL fails, the REST API. A rejected
+// token falls back to anonymous access and says so in TokenWarning.
+func (c *Client) ListRepos(ctx context.Context, owner string) ([]*Repository, ListInfo, error) {
+	if c.opts.Provider == GitLab {
+		return c.listGitLab(ctx, owner)
+	}
+
+	api := c.api
+	var info ListInfo
+	if c.opts.Token != "" {
+		if !validateToken(ctx, api, &info) {
+			// a rejected token fails every request, anonymous still works
+			api = c.newAPI("")
+		} else {
+			repos, err := c.listGraphQL(ctx, api, owner, &info)
+			if err == nil {
+				return repos, info, nil
+			}
+			c.opts.Logger.Warn("graphql listing failed, falling back to rest", "err", err)
+		}
+	}
+
+	opt := &github.RepositoryListOptions{
+		Type:        "all",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var allRepos []*github.Repository
+	for {
+		repos, resp, err := api.ListRepos(ctx, owner, opt)
+		if err != nil {
+			return nil, info, fmt.Errorf("failed to list repos: %w", err)
+		}
+		info.Rate = resp.Rate
+		allRepos = append(allRepos, repos...)
+		c.opts.Logger.Debug("fetched repo page", "user", owner, "page", opt.Page, "count", len(repos), "next", resp.NextPage)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return allRepos, info, nil
+}
+
+// validateToken fills in the login and scopes of the configured token and
+// reports whether the token was accepted at all.
+func validateToken(ctx context.Context, api API, info *ListInfo) bool {
+	user, resp, err := api.CurrentUser(ctx)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			info.TokenWarning = "token was rejected (expired or revoked), browsing anonymously"
+			return false
+		}
+		info.TokenWarning = fmt.Sprintf("could not validate token: %v", err)
+		return true
+	}
+
+	info.Login = user.GetLogin()
+	if header := resp.Header.Get("X-OAuth-Scopes"); header != "" {
+		for _, scope := range strings.Split(header, ",") {
+			info.Scopes = append(info.Scopes, strings.TrimSpace(scope))
+		}
+		if !slices.Contains(info.Scopes, "repo") {
+			info.TokenWarning = "token lacks the repo scope, private repositories are hidden"
+		}
+	}
+
+	if exp := resp.TokenExpiration.Time; !exp.IsZero() && time.Until(exp) < 7*24*time.Hour {
+		info.TokenWarning = fmt.Sprintf("token expires %s", exp.Format("2006-01-02"))
+	}
+	return true
+}
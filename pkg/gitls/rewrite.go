@@ -0,0 +1,25 @@
+package gitls
+
+import "strings"
+
+// Rewrite replaces the prefix From of a clone URL with To, like git's
+// url.<base>.insteadOf, e.g. to clone over SSH or from a mirror.
+type Rewrite struct {
+	From string
+	To   string
+}
+
+// RewriteURL applies the rule whose From is the longest prefix of url.
+// Without a match url is returned unchanged.
+func RewriteURL(url string, rules []Rewrite) string {
+	best := -1
+	for i, r := range rules {
+		if r.From != "" && strings.HasPrefix(url, r.From) && (best < 0 || len(r.From) > len(rules[best].From)) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return url
+	}
+	return rules[best].To + strings.TrimPrefix(url, rules[best].From)
+}
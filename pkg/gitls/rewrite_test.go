@@ -0,0 +1,28 @@
+package gitls
+
+import "testing"
+
+func TestRewriteURL(t *testing.T) {
+	rules := []Rewrite{
+		{From: "https://github.com/", To: "git@github.com:"},
+		{From: "https://github.com/corp/", To: "https://mirror.example.com/corp/"},
+		{From: "", To: "ignored"},
+	}
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/alice/tool.git", "git@github.com:alice/tool.git"},
+		{"https://github.com/corp/app.git", "https://mirror.example.com/corp/app.git"}, // longest prefix wins
+		{"https://gitlab.com/alice/tool.git", "https://gitlab.com/alice/tool.git"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := RewriteURL(tt.url, rules); got != tt.want {
+			t.Errorf("RewriteURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+	if got := RewriteURL("https://github.com/a/b.git", nil); got != "https://github.com/a/b.git" {
+		t.Errorf("RewriteURL without rules = %q", got)
+	}
+}
@@ -0,0 +1,83 @@
+package gitls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// Affiliations accepted by ListOwnRepos.
+const (
+	AffiliationOwner        = "owner"
+	AffiliationCollaborator = "collaborator"
+	AffiliationOrgMember    = "organization_member"
+)
+
+var errGitHubOnly = errors.New("only supported for GitHub")
+
+// ListOwnRepos lists the repositories the authenticated user can access,
+// limited to a comma-separated list of affiliations (all when empty).
+func (c *Client) ListOwnRepos(ctx context.Context, affiliation string) ([]*Repository, error) {
+	if c.opts.Provider != GitHub {
+		return nil, fmt.Errorf("affiliation filters: %w", errGitHubOnly)
+	}
+	opt := &github.RepositoryListOptions{
+		Affiliation: affiliation,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var all []*Repository
+	for {
+		// an empty user lists the authenticated user's repositories
+		repos, resp, err := c.api.ListRepos(ctx, "", opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repos: %w", err)
+		}
+		all = append(all, repos...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// ListTeams lists the teams of org visible to the authenticated user.
+func (c *Client) ListTeams(ctx context.Context, org string) ([]*github.Team, error) {
+	if c.opts.Provider != GitHub {
+		return nil, fmt.Errorf("teams: %w", errGitHubOnly)
+	}
+	opt := &github.ListOptions{PerPage: 100}
+	var all []*github.Team
+	for {
+		teams, resp, err := c.api.ListTeams(ctx, org, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list teams: %w", err)
+		}
+		all = append(all, teams...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// ListTeamRepos lists the repositories of the team with the given slug.
+func (c *Client) ListTeamRepos(ctx context.Context, org, team string) ([]*Repository, error) {
+	if c.opts.Provider != GitHub {
+		return nil, fmt.Errorf("teams: %w", errGitHubOnly)
+	}
+	opt := &github.ListOptions{PerPage: 100}
+	var all []*Repository
+	for {
+		repos, resp, err := c.api.ListTeamRepos(ctx, org, team, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list team repos: %w", err)
+		}
+		all = append(all, repos...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}